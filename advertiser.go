@@ -0,0 +1,254 @@
+package appnexus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// AdvertiserService handles all requests to the advertiser service API
+type AdvertiserService struct {
+	*Response
+	client *Client
+}
+
+// Label is a name and value reporting label attached to an object such as
+// an advertiser, insertion order or line item
+type Label struct {
+	ID    int    `json:"id,omitempty"`
+	Name  string `json:"name,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+// Advertiser is a company buying media through a member
+type Advertiser struct {
+	ID                   int     `json:"id,omitempty"`
+	Code                 string  `json:"code,omitempty"`
+	Name                 string  `json:"name,omitempty"`
+	State                string  `json:"state,omitempty"`
+	DefaultBrandID       int     `json:"default_brand_id,omitempty"`
+	RemarketingSegmentID int     `json:"remarketing_segment_id,omitempty"`
+	LifetimeBudget       float64 `json:"lifetime_budget,omitempty"`
+	LifetimeBudgetImps   int     `json:"lifetime_budget_imps,omitempty"`
+	DailyBudget          float64 `json:"daily_budget,omitempty"`
+	DailyBudgetImps      int     `json:"daily_budget_imps,omitempty"`
+	EnablePacing         bool    `json:"enable_pacing,omitempty"`
+	ProfileID            int     `json:"profile_id,omitempty"`
+	ControlPct           float64 `json:"control_pct,omitempty"`
+	Timezone             string  `json:"timezone,omitempty"`
+	TimeFormat           string  `json:"time_format,omitempty"`
+	DefaultCurrency      string  `json:"default_currency,omitempty"`
+	UseInsertionOrders   bool    `json:"use_insertion_orders,omitempty"`
+	BillingName          string  `json:"billing_name,omitempty"`
+	BillingPhone         string  `json:"billing_phone,omitempty"`
+	BillingAddress1      string  `json:"billing_address1,omitempty"`
+	BillingAddress2      string  `json:"billing_address2,omitempty"`
+	BillingCity          string  `json:"billing_city,omitempty"`
+	BillingState         string  `json:"billing_state,omitempty"`
+	BillingCountry       string  `json:"billing_country,omitempty"`
+	BillingZip           string  `json:"billing_zip,omitempty"`
+	Labels               []Label `json:"labels,omitempty"`
+	LastModified         string  `json:"last_modified,omitempty"`
+}
+
+type advertiserResponse struct {
+	*http.Response
+	Obj struct {
+		Advertiser  Advertiser   `json:"advertiser,omitempty"`
+		Advertisers []Advertiser `json:"advertisers,omitempty"`
+		Error       string       `json:"error"`
+		Status      string       `json:"status"`
+		Service     string       `json:"service"`
+		Rate        Rate         `json:"dbg_info"`
+	} `json:"response"`
+}
+
+// advertiserPath returns the path addressing an advertiser of the member by
+// ID, or by code when it has no ID
+func advertiserPath(memberID int, id int, code string) (string, error) {
+	switch {
+	case id > 0:
+		return fmt.Sprintf("advertiser?member_id=%d&id=%d", memberID, id), nil
+	case code != "":
+		return fmt.Sprintf("advertiser?member_id=%d&code=%s", memberID, url.QueryEscape(code)), nil
+	}
+
+	return "", errors.New("Advertiser requires an ID or code")
+}
+
+// Get an advertiser from the advertiser service by Member ID and Advertiser ID
+func (s *AdvertiserService) Get(memberID int, advertiserID int) (*Advertiser, error) {
+	return s.GetContext(context.Background(), memberID, advertiserID)
+}
+
+// GetContext is like Get but honours cancellation and deadlines on ctx
+func (s *AdvertiserService) GetContext(ctx context.Context, memberID int, advertiserID int) (*Advertiser, error) {
+	return s.get(ctx, memberID, advertiserID, "")
+}
+
+// GetByCode gets an advertiser from the advertiser service by Member ID and
+// Advertiser code
+func (s *AdvertiserService) GetByCode(memberID int, code string) (*Advertiser, error) {
+	return s.GetByCodeContext(context.Background(), memberID, code)
+}
+
+// GetByCodeContext is like GetByCode but honours cancellation and deadlines
+// on ctx
+func (s *AdvertiserService) GetByCodeContext(ctx context.Context, memberID int, code string) (*Advertiser, error) {
+	return s.get(ctx, memberID, 0, code)
+}
+
+func (s *AdvertiserService) get(ctx context.Context, memberID int, advertiserID int, code string) (*Advertiser, error) {
+
+	path, err := advertiserPath(memberID, advertiserID, code)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := s.client.newRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &advertiserResponse{}
+	_, err = s.client.do(req, r)
+	if err != nil {
+		return nil, err
+	}
+
+	advertiser := &r.Obj.Advertiser
+	return advertiser, nil
+}
+
+// List the advertisers of a member
+func (s *AdvertiserService) List(memberID int, opt *ListOptions) ([]Advertiser, *Response, error) {
+	return s.ListContext(context.Background(), memberID, opt)
+}
+
+// ListContext is like List but honours cancellation and deadlines on ctx
+func (s *AdvertiserService) ListContext(ctx context.Context, memberID int, opt *ListOptions) ([]Advertiser, *Response, error) {
+	u, err := addOptions(fmt.Sprintf("advertiser?member_id=%d", memberID), opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.newRequest(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	advertisers := &advertiserResponse{}
+	resp, err := s.client.do(req, advertisers)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return advertisers.Obj.Advertisers, resp, err
+}
+
+// Iter returns an Iterator over every advertiser of the member
+func (s *AdvertiserService) Iter(memberID int, opt *ListOptions) *Iterator[Advertiser] {
+	return s.IterContext(context.Background(), memberID, opt)
+}
+
+// IterContext is like Iter but stops once ctx is done
+func (s *AdvertiserService) IterContext(ctx context.Context, memberID int, opt *ListOptions) *Iterator[Advertiser] {
+	return newIterator(ctx, opt, func(ctx context.Context, opt *ListOptions) ([]Advertiser, *Response, error) {
+		return s.ListContext(ctx, memberID, opt)
+	})
+}
+
+// ListAll fetches every advertiser of the member, walking through all pages
+func (s *AdvertiserService) ListAll(memberID int, opt *ListOptions) ([]Advertiser, error) {
+	return s.ListAllContext(context.Background(), memberID, opt)
+}
+
+// ListAllContext is like ListAll but honours cancellation and deadlines on
+// ctx
+func (s *AdvertiserService) ListAllContext(ctx context.Context, memberID int, opt *ListOptions) ([]Advertiser, error) {
+	return listAll(ctx, opt, func(ctx context.Context, opt *ListOptions) ([]Advertiser, *Response, error) {
+		return s.ListContext(ctx, memberID, opt)
+	})
+}
+
+// Add a new advertiser
+func (s *AdvertiserService) Add(memberID int, item *Advertiser) (*Response, error) {
+	return s.AddContext(context.Background(), memberID, item)
+}
+
+// AddContext is like Add but honours cancellation and deadlines on ctx
+func (s *AdvertiserService) AddContext(ctx context.Context, memberID int, item *Advertiser) (*Response, error) {
+
+	data := struct {
+		Advertiser `json:"advertiser"`
+	}{*item}
+
+	req, err := s.client.newRequest(ctx, "POST", fmt.Sprintf("advertiser?member_id=%d", memberID), data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Response{}
+	resp, err := s.client.do(req, result)
+	if err != nil {
+		return resp, err
+	}
+
+	item.ID = result.Obj.ID
+	return result, nil
+}
+
+// Update an existing advertiser, given by ID or code, with new data
+func (s *AdvertiserService) Update(memberID int, item Advertiser) (*Response, error) {
+	return s.UpdateContext(context.Background(), memberID, item)
+}
+
+// UpdateContext is like Update but honours cancellation and deadlines on ctx
+func (s *AdvertiserService) UpdateContext(ctx context.Context, memberID int, item Advertiser) (*Response, error) {
+
+	data := struct {
+		Advertiser `json:"advertiser"`
+	}{item}
+
+	path, err := advertiserPath(memberID, item.ID, item.Code)
+	if err != nil {
+		return nil, errors.New("Update Advertiser requires an advertiser to have an ID or code already")
+	}
+
+	req, err := s.client.newRequest(ctx, "PUT", path, data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Response{}
+	resp, err := s.client.do(req, result)
+	if err != nil {
+		return resp, err
+	}
+
+	return result, nil
+}
+
+// Delete the specified advertiser, given by ID or code
+func (s *AdvertiserService) Delete(memberID int, item Advertiser) error {
+	return s.DeleteContext(context.Background(), memberID, item)
+}
+
+// DeleteContext is like Delete but honours cancellation and deadlines on ctx
+func (s *AdvertiserService) DeleteContext(ctx context.Context, memberID int, item Advertiser) error {
+
+	path, err := advertiserPath(memberID, item.ID, item.Code)
+	if err != nil {
+		return errors.New("Delete Advertiser requires an advertiser to have an ID or code already")
+	}
+
+	req, err := s.client.newRequest(ctx, "DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.do(req, nil)
+	return err
+}
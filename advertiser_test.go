@@ -0,0 +1,191 @@
+package appnexus
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestAdvertiserService_Get(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/advertiser", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("member_id") != "1" || r.URL.Query().Get("id") != "5" {
+			t.Errorf("Advertisers.Get requested %s, expected member_id=1&id=5", r.URL.RawQuery)
+		}
+
+		fmt.Fprint(w, `{"response":
+            {"status":"OK",
+            "advertiser": {
+                "id": 5,
+                "code": "acme",
+                "name": "Acme Corp",
+                "state": "active",
+                "labels": [{"id": 1, "name": "Salesperson", "value": "Wile"}]
+            }}}`)
+	})
+
+	actual, err := client.Advertisers.Get(1, 5)
+	if err != nil {
+		t.Errorf("Advertisers.Get returned error: %v", err)
+	}
+
+	if actual.ID != 5 || actual.Name != "Acme Corp" || len(actual.Labels) != 1 || actual.Labels[0].Value != "Wile" {
+		t.Errorf("Advertisers.Get returned %+v", actual)
+	}
+}
+
+func TestAdvertiserService_GetByCode(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/advertiser", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("code") != "acme & co" || r.URL.Query().Get("id") != "" {
+			t.Errorf("Advertisers.GetByCode requested %s, expected code=acme+%%26+co", r.URL.RawQuery)
+		}
+
+		fmt.Fprint(w, `{"response": {"status":"OK", "advertiser": {"id": 5, "code": "acme & co"}}}`)
+	})
+
+	actual, err := client.Advertisers.GetByCode(1, "acme & co")
+	if err != nil {
+		t.Errorf("Advertisers.GetByCode returned error: %v", err)
+	}
+
+	if actual.ID != 5 {
+		t.Errorf("Advertisers.GetByCode returned %+v, expected ID 5", actual)
+	}
+}
+
+func TestAdvertiserService_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/advertiser", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("member_id") != "1" || q.Get("start_element") != "2" || q.Get("num_elements") != "2" {
+			t.Errorf("Advertisers.List requested %s, expected member_id and paging parameters", r.URL.RawQuery)
+		}
+
+		fmt.Fprint(w, `{"response":
+            {"status":"OK",
+            "count": 3,
+            "start_element": 2,
+            "num_elements": 2,
+            "advertisers": [{"id": 3, "name": "Advertiser 3"}]}}`)
+	})
+
+	actual, _, err := client.Advertisers.List(1, &ListOptions{StartElement: 2, NumElements: 2})
+	if err != nil {
+		t.Errorf("Advertisers.List returned error: %v", err)
+	}
+
+	if len(actual) != 1 || actual[0].ID != 3 || actual[0].Name != "Advertiser 3" {
+		t.Errorf("Advertisers.List returned %+v", actual)
+	}
+}
+
+func TestAdvertiserService_ListAll(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/advertiser", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("start_element") {
+		case "", "0":
+			fmt.Fprint(w, `{"response": {"status":"OK", "count": 3, "start_element": 0, "num_elements": 2,
+                "advertisers": [{"id": 1}, {"id": 2}]}}`)
+		case "2":
+			fmt.Fprint(w, `{"response": {"status":"OK", "count": 3, "start_element": 2, "num_elements": 2,
+                "advertisers": [{"id": 3}]}}`)
+		default:
+			t.Errorf("Advertisers.ListAll requested %s", r.URL.RawQuery)
+		}
+	})
+
+	actual, err := client.Advertisers.ListAll(1, &ListOptions{NumElements: 2})
+	if err != nil {
+		t.Errorf("Advertisers.ListAll returned error: %v", err)
+	}
+
+	if len(actual) != 3 || actual[2].ID != 3 {
+		t.Errorf("Advertisers.ListAll returned %+v, expected 3 advertisers", actual)
+	}
+}
+
+func TestAdvertiserService_Add(t *testing.T) {
+	setup()
+	defer teardown()
+
+	data := Advertiser{
+		Name:  "Acme Corp",
+		Code:  "acme",
+		State: "active",
+	}
+
+	mux.HandleFunc("/advertiser", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Query().Get("member_id") != "1" {
+			t.Errorf("Advertisers.Add sent %s %s", r.Method, r.URL)
+		}
+
+		var body struct {
+			Advertiser Advertiser `json:"advertiser"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Advertiser.Name != "Acme Corp" {
+			t.Errorf("Advertisers.Add sent %+v (%v)", body, err)
+		}
+
+		fmt.Fprint(w, `{"response": {"status":"OK", "id": 7 }}`)
+	})
+
+	actual, err := client.Advertisers.Add(1, &data)
+	if err != nil {
+		t.Errorf("Advertisers.Add returned error: %v", err)
+	}
+
+	if actual.Obj.ID != 7 || data.ID != 7 {
+		t.Errorf("Advertisers.Add returned %+v and set ID %d, expected 7", actual.Obj, data.ID)
+	}
+}
+
+func TestAdvertiserService_Update(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/advertiser", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Query().Get("code") != "acme" {
+			t.Errorf("Advertisers.Update sent %s %s", r.Method, r.URL)
+		}
+
+		fmt.Fprint(w, `{"response": {"status":"OK" }}`)
+	})
+
+	actual, err := client.Advertisers.Update(1, Advertiser{Code: "acme", Name: "Acme Inc"})
+	if err != nil {
+		t.Errorf("Advertisers.Update returned error: %v", err)
+	}
+
+	if actual.Obj.Status != "OK" {
+		t.Errorf("Advertisers.Update returned %+v", actual)
+	}
+
+	if _, err := client.Advertisers.Update(1, Advertiser{Name: "Nobody"}); err == nil {
+		t.Error("Advertisers.Update accepted an advertiser without an ID or code")
+	}
+}
+
+func TestAdvertiserService_Delete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/advertiser", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" || r.URL.Query().Get("id") != "5" {
+			t.Errorf("Advertisers.Delete sent %s %s", r.Method, r.URL)
+		}
+	})
+
+	if err := client.Advertisers.Delete(1, Advertiser{ID: 5}); err != nil {
+		t.Errorf("Advertisers.Delete returned error: %v", err)
+	}
+}
@@ -2,14 +2,17 @@ package appnexus
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"reflect"
+	"sync"
 	"time"
 
 	"github.com/google/go-querystring/query"
@@ -21,18 +24,78 @@ type credentials struct {
 	Password string `json:"password"`
 }
 
-// Client used to make HTTP requests
+// Client used to make HTTP requests. A Client is safe for concurrent use by
+// multiple goroutines, which then share its authentication and rate limits.
 type Client struct {
-	client      *http.Client
-	EndPoint    *url.URL
-	Rate        Rate
+	client   *http.Client
+	EndPoint *url.URL
+
+	// Rate holds the rate limit information from the latest response.
+	//
+	// Deprecated: use CurrentRate, which is safe for concurrent use.
+	Rate Rate
+
 	UserAgent   string
+	RetryPolicy RetryPolicy
+
+	// MemberID is the working member, set by Members.GetDefault.
+	//
+	// Deprecated: use CurrentMemberID, which is safe for concurrent use.
+	MemberID int
+
+	logger  *slog.Logger
+	timeout time.Duration
+
+	mu          sync.Mutex
 	token       string
+	tokens      TokenStore
+	tokenLoad   chan struct{}
 	credentials credentials
-	MemberID    int
+	login       *loginCall
+	reauthLimit int
+	limiter     rateLimiter
+	middleware  []Middleware
+	observer    Observer
+
+	Members         *MemberService
+	Segments        *SegmentService
+	BatchSegments   *BatchSegmentService
+	Advertisers     *AdvertiserService
+	InsertionOrders *InsertionOrderService
+	LineItems       *LineItemService
+	Campaigns       *CampaignService
+	Profiles        *ProfileService
+	Creatives       *CreativeService
+	Publishers      *PublisherService
+	Sites           *SiteService
+	Placements      *PlacementService
+	PaymentRules    *PaymentRuleService
+	YMProfiles      *YMProfileService
+	YMFloors        *YMFloorService
+	Deals           *DealService
+	DealBuyerAccess *DealBuyerAccessService
+	DomainLists     *DomainListService
+}
 
-	Members  *MemberService
-	Segments *SegmentService
+// DebugInfo is the dbg_info object returned with every AppNexus response,
+// describing how the request was served and the rate limits in operation
+type DebugInfo struct {
+	Rate
+	Instance              string        `json:"instance,omitempty"`
+	MasterInstance        string        `json:"master_instance,omitempty"`
+	SlaveHit              bool          `json:"slave_hit,omitempty"`
+	SlaveLag              float64       `json:"slave_lag,omitempty"`
+	DB                    string        `json:"db,omitempty"`
+	AwesomesauceCacheUsed bool          `json:"awesomesauce_cache_used,omitempty"`
+	CountCacheUsed        bool          `json:"count_cache_used,omitempty"`
+	UUID                  string        `json:"uuid,omitempty"`
+	Warnings              []interface{} `json:"warnings,omitempty"`
+	ProcessingTime        float64       `json:"time,omitempty"`
+	StartMicrotime        float64       `json:"start_microtime,omitempty"`
+	Version               string        `json:"version,omitempty"`
+	MemberLastModifiedAge float64       `json:"member_last_modified_age,omitempty"`
+	OutputTerm            string        `json:"output_term,omitempty"`
+	ParentDebugInfo       *DebugInfo    `json:"parent_dbg_info,omitempty"`
 }
 
 // Rate contains information on the current rate limit in operation
@@ -49,6 +112,7 @@ type Rate struct {
 // Response is a AppNexus API response object
 type Response struct {
 	*http.Response
+	raw []byte
 	Obj struct {
 		Status           string    `json:"status"`
 		ID               int       `json:"id,omitempty"`
@@ -64,7 +128,7 @@ type Response struct {
 		NumElements      int       `json:"num_elements,omitempty"`
 		Member           Member    `json:"member,omitempty"`
 		Segments         []Segment `json:"segments,omitempty"`
-		Rate             Rate      `json:"dbg_info"`
+		DebugInfo        `json:"dbg_info"`
 	} `json:"response"`
 }
 
@@ -76,8 +140,9 @@ type ListOptions struct {
 	Active       bool `url:"active,omitempty"`
 }
 
-// NewClient returns a new AppNexus API client
-func NewClient(endPointURL string) (*Client, error) {
+// NewClient returns a new AppNexus API client, configured by any options
+// given
+func NewClient(endPointURL string, opts ...ClientOption) (*Client, error) {
 
 	httpClient := http.DefaultClient
 	baseURL, err := url.Parse(endPointURL)
@@ -86,19 +151,53 @@ func NewClient(endPointURL string) (*Client, error) {
 	}
 
 	c := &Client{
-		client:    httpClient,
-		EndPoint:  baseURL,
-		UserAgent: "github.com/adwww/appnexus go-appnexus-client",
+		client:      httpClient,
+		EndPoint:    baseURL,
+		UserAgent:   "github.com/adwww/appnexus go-appnexus-client",
+		RetryPolicy: DefaultRetryPolicy,
+		logger:      slog.New(discardHandler{}),
+		reauthLimit: 1,
+		observer:    nopObserver{},
+	}
+
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.timeout > 0 {
+		hc := *c.client
+		hc.Timeout = c.timeout
+		c.client = &hc
 	}
 
 	c.Members = &MemberService{client: c}
 	c.Segments = &SegmentService{client: c}
+	c.BatchSegments = &BatchSegmentService{client: c}
+	c.Advertisers = &AdvertiserService{client: c}
+	c.InsertionOrders = &InsertionOrderService{client: c}
+	c.LineItems = &LineItemService{client: c}
+	c.Campaigns = &CampaignService{client: c}
+	c.Profiles = &ProfileService{client: c}
+	c.Creatives = &CreativeService{client: c}
+	c.Publishers = &PublisherService{client: c}
+	c.Sites = &SiteService{client: c}
+	c.Placements = &PlacementService{client: c}
+	c.PaymentRules = &PaymentRuleService{client: c}
+	c.YMProfiles = &YMProfileService{client: c}
+	c.YMFloors = &YMFloorService{client: c}
+	c.Deals = &DealService{client: c}
+	c.DealBuyerAccess = &DealBuyerAccessService{client: c}
+	c.DomainLists = &DomainListService{client: c}
 
 	return c, nil
 }
 
-// NewRequest creates an API request using a relative URL
-func (c *Client) newRequest(method, path string, body interface{}) (*http.Request, error) {
+// NewRequest creates an API request using a relative URL. The request carries
+// ctx so that cancellation and deadlines apply to the whole API call. The
+// body is JSON encoded, unless it is an io.Reader which is sent as is.
+func (c *Client) newRequest(ctx context.Context, method, path string, body interface{}) (*http.Request, error) {
 	rel, err := url.Parse(path)
 	if err != nil {
 		return nil, err
@@ -106,24 +205,66 @@ func (c *Client) newRequest(method, path string, body interface{}) (*http.Reques
 
 	u := c.EndPoint.ResolveReference(rel)
 
-	var buf io.ReadWriter
-	if body != nil {
-		buf = new(bytes.Buffer)
-		err := json.NewEncoder(buf).Encode(body)
+	var buf io.Reader
+	switch b := body.(type) {
+	case nil:
+	case io.Reader:
+		buf = b
+	default:
+		enc := new(bytes.Buffer)
+		err := json.NewEncoder(enc).Encode(body)
 		if err != nil {
 			return nil, err
 		}
+		buf = enc
 	}
 
-	req, err := http.NewRequest(method, u.String(), buf)
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), buf)
 	if err != nil {
 		return nil, err
 	}
 
+	// Raw bodies that can be read at an offset, like *os.File, are sent and
+	// replayed through section readers of their own, so that nothing else
+	// reading the body, such as LogTraffic, can drain the one being sent:
+	if ra, ok := buf.(interface {
+		io.ReaderAt
+		io.Seeker
+	}); ok && req.GetBody == nil {
+		start, err := ra.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return nil, err
+		}
+
+		end, err := ra.Seek(0, io.SeekEnd)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := ra.Seek(start, io.SeekStart); err != nil {
+			return nil, err
+		}
+
+		size := end - start
+		req.GetBody = func() (io.ReadCloser, error) {
+			if size == 0 {
+				return http.NoBody, nil
+			}
+			return ioutil.NopCloser(io.NewSectionReader(ra, start, size)), nil
+		}
+
+		req.Body, _ = req.GetBody()
+		req.ContentLength = size
+	}
+
+	if _, ok := body.(io.Reader); body != nil && !ok {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
 	req.Header.Add("User-Agent", c.UserAgent)
 
-	if c.token != "" {
-		req.Header.Add("Authorization", c.token)
+	if token := c.loadToken(ctx); token != "" {
+		req.Header.Add("Authorization", token)
 	}
 
 	return req, nil
@@ -133,118 +274,250 @@ func (c *Client) newRequest(method, path string, body interface{}) (*http.Reques
 // JSON decoded and stored in the value pointed to by v, or returned as an
 // error if an API error has occurred.  If v implements the io.Writer
 // interface, the raw response body will be written to v, without attempting to
-// first decode it.
-func (c *Client) do(req *http.Request, v interface{}) (*Response, error) {
+// first decode it. The request's context is honoured while waiting for the
+// rate limit as well as during the HTTP exchange itself. Transient failures
+// are retried according to the client's RetryPolicy, and the whole call is
+// reported to the client's Observer.
+func (c *Client) do(req *http.Request, v interface{}) (response *Response, err error) {
+
+	call := c.newCall(req)
+	ctx := c.observer.CallStarted(req.Context(), call)
+	req = req.WithContext(ctx)
+
+	defer func() {
+		c.observer.CallFinished(ctx, call, err)
+	}()
+
+	response, err = c.sendWithRetry(req, call)
+
+	// If the call failed with a NOAUTH error, reauthenticate and replay the
+	// request with the new token, giving up after ReauthLimit logins:
+	for reauths := 0; errors.Is(err, ErrNoAuth) && !isAuthRequest(req) && canReplay(req); reauths++ {
+		if reauths >= c.reauthLimit {
+			return nil, fmt.Errorf("%w after %d attempts: %w", ErrReauthFailed, reauths, err)
+		}
+
+		c.logger.InfoContext(ctx, "appnexus: re-authenticating", "method", req.Method, "path", req.URL.Path)
+
+		token, loginErr := c.reauthenticate(ctx, req.Header.Get("Authorization"))
+		if loginErr != nil {
+			return nil, fmt.Errorf("could not reauthenticate: %w: %w", loginErr, err)
+		}
+
+		req = req.Clone(ctx)
+		req.Header.Set("Authorization", token)
+		response, err = c.sendWithRetry(req, call)
+	}
 
-	_ = c.waitForRateLimit(req.Method)
+	if err != nil {
+		return nil, err
+	}
+
+	if v != nil {
+		err := json.Unmarshal(response.Raw(), v)
+		if err != nil {
+			return nil, fmt.Errorf("client.do.unmarshal: %w", err)
+		}
+	}
+
+	return response, nil
+}
+
+// sendWithRetry sends the request, replaying it with a fresh body while the
+// failure is transient and the retry policy allows another attempt
+func (c *Client) sendWithRetry(req *http.Request, call *Call) (*Response, error) {
+	for attempt := 1; ; attempt++ {
+		r, err := rewindRequest(req)
+		if err != nil {
+			return nil, fmt.Errorf("client.do.rewind: %w", err)
+		}
+
+		call.Attempts++
+		response, err := c.send(r)
+
+		call.Response = response
+		var apiErr *Error
+		if errors.As(err, &apiErr) {
+			call.Response = apiErr.Response
+		}
+
+		wait, retry := c.RetryPolicy.backoff(req.Method, attempt, err)
+		if !retry || !canReplay(req) {
+			return response, err
+		}
+
+		c.observer.Retried(req.Context(), call, err, wait)
+
+		c.logger.WarnContext(req.Context(), "appnexus: retrying request",
+			"method", req.Method, "path", req.URL.Path, "attempt", attempt, "wait", wait, "error", err)
+
+		if err := sleepContext(req.Context(), wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// send makes a single attempt at the request through the middleware chain
+// once the rate limit allows it
+func (c *Client) send(req *http.Request) (*Response, error) {
+
+	if _, err := c.waitForRateLimit(req.Context(), req.Method); err != nil {
+		return nil, err
+	}
+
+	response, err := c.handler()(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// exchange is the innermost Handler, performing the HTTP exchange and
+// decoding the AppNexus response
+func (c *Client) exchange(req *http.Request) (*Response, error) {
 
 	resp, err := c.client.Do(req)
 	if err != nil {
-		return nil, errors.New("client.do.do: " + err.Error())
+		return nil, fmt.Errorf("client.do.do: %w", err)
 	}
 
 	defer resp.Body.Close()
 
 	data, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return nil, errors.New("client.do.readall: " + err.Error())
+		return nil, fmt.Errorf("client.do.readall: %w", err)
 	}
 
 	response, err := c.checkResponse(resp, data)
+	if response == nil {
+		response = &Response{Response: resp}
+	}
+
+	response.raw = data
+	resp.Body = ioutil.NopCloser(bytes.NewReader(data))
+
 	if err != nil {
+		return response, fmt.Errorf("client.do.checkResponse: %w", err)
+	}
 
-		// If the call failed with a NOAUTH error, attempt to reauthenticate
-		// and try the request again:
-		if response.Obj.ErrorID == "NOAUTH" && req.URL.Path != "auth" {
-			c.token = ""
-			err = c.Login(c.credentials.Username, c.credentials.Password)
-			if err != nil {
-				return nil, errors.New("Could not reauthenticate:\n" + err.Error())
-			}
+	return response, nil
+}
 
-			return c.do(req, v)
-		}
+// Wait for a Write or Read token from the rate limiter shared by every caller
+// of the client, or until ctx is done
+func (c *Client) waitForRateLimit(ctx context.Context, method string) (time.Duration, error) {
 
-		return nil, errors.New("client.do.checkResponse: " + err.Error())
+	duration := c.limiter.reserve(method, time.Now())
+	if duration > 0 {
+		c.observer.RateLimitWaited(ctx, method, duration)
 	}
 
-	if v != nil {
-		err := json.Unmarshal(data, v)
-		if err != nil {
-			return nil, errors.New("client.do.unmarshal: " + err.Error())
-		}
+	if err := sleepContext(ctx, duration); err != nil {
+		c.limiter.cancel(method)
+		return duration, err
 	}
 
-	return response, nil
+	return duration, nil
 }
 
-// Wait for the Write or Read rate limit timeout
-func (c *Client) waitForRateLimit(method string) time.Duration {
+// CurrentRate returns the rate limit information from the latest response
+func (c *Client) CurrentRate() Rate {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
-	var duration time.Duration
+	return c.Rate
+}
 
-	// Write limit for POST, PUT, DELETE:
-	limit := c.Rate.WriteLimit
-	actions := c.Rate.Writes
-	period := c.Rate.WriteLimitSeconds
+// CurrentMemberID returns the working member set by Members.GetDefault
+func (c *Client) CurrentMemberID() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
-	// Read limit for GET:
-	if method == "GET" {
-		limit = c.Rate.ReadLimit
-		actions = c.Rate.Reads
-		period = c.Rate.ReadLimitSeconds
-	}
+	return c.MemberID
+}
 
-	// More actions than the limit on the requested operation:
-	if actions >= limit && time.Now().Unix() < c.Rate.Time.Add(time.Duration(period)*time.Second).Unix() {
-		duration = c.Rate.Time.Add(time.Duration(period) * time.Second).Sub(time.Now())
-		time.Sleep(duration)
-	}
+// setMemberID sets the working member
+func (c *Client) setMemberID(id int) {
+	c.mu.Lock()
+	c.MemberID = id
+	c.mu.Unlock()
+}
 
-	return duration
+// setRate records the rate limit information from a response and passes it
+// on to the rate limiter
+func (c *Client) setRate(r Rate) {
+	c.mu.Lock()
+	c.Rate = r
+	c.mu.Unlock()
+
+	c.limiter.update(r, r.Time)
+}
+
+// setToken replaces the current authentication token
+func (c *Client) setToken(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.token = token
 }
 
 // CheckResponse checks the API response for errors, and returns them if
-// present.
+// present. API errors are returned as *Error.
 func (c *Client) checkResponse(r *http.Response, data []byte) (*Response, error) {
 	var resp *Response
-	if r.StatusCode < 200 || r.StatusCode > 299 {
-		return nil, errors.New(r.Status)
-	}
+	failed := r.StatusCode < 200 || r.StatusCode > 299
 
 	if len(data) > 0 {
 
 		resp = &Response{Response: r}
 		err := json.Unmarshal(data, resp)
 		if err != nil {
+			if failed {
+				return nil, newError(r, nil)
+			}
 			return nil, err
 		}
 
-		c.Rate = resp.Obj.Rate
-		c.Rate.Time = time.Now()
+		rate := resp.Obj.Rate
+		rate.Time = time.Now()
+		c.setRate(rate)
+		c.observer.RateUpdated(r.Request.Context(), rate)
 
-		if resp.Obj.ErrorID != "" || resp.Obj.Error != "" {
-			str := fmt.Sprintf("AppNexus:checkResponse [%s]: %s", resp.Obj.ErrorID, resp.Obj.Error)
-			return resp, errors.New(str)
+		if failed || resp.Obj.ErrorID != "" || resp.Obj.Error != "" {
+			return resp, newError(r, resp)
 		}
 	}
 
+	if failed {
+		return nil, newError(r, nil)
+	}
+
 	return resp, nil
 }
 
 // Login to the AppNexus API and get an authentication token
 func (c *Client) Login(username string, password string) error {
+	return c.LoginContext(context.Background(), username, password)
+}
 
-	c.credentials = credentials{
+// LoginContext is like Login but honours cancellation and deadlines on ctx
+func (c *Client) LoginContext(ctx context.Context, username string, password string) error {
+
+	creds := credentials{
 		Username: username,
 		Password: password,
 	}
 
+	c.mu.Lock()
+	c.credentials = creds
+	c.mu.Unlock()
+
 	auth := struct {
 		credentials `json:"auth"`
-	}{c.credentials}
+	}{creds}
 
-	req, err := c.newRequest("POST", "auth", auth)
+	req, err := c.newRequest(ctx, "POST", "auth", auth)
 	if err != nil {
 		return err
 	}
@@ -254,12 +527,24 @@ func (c *Client) Login(username string, password string) error {
 		return err
 	}
 
-	c.token = resp.Cookies()[0].Value
+	token := resp.Obj.Token
+	if token == "" {
+		if cookies := resp.Cookies(); len(cookies) > 0 {
+			token = cookies[0].Value
+		}
+	}
+
+	if token == "" {
+		return errors.New("Login succeeded without returning a token")
+	}
+
+	c.saveToken(ctx, token)
 	return nil
 }
 
-// addOptions adds the parameters in opt as URL query parameters to s.  opt
-// must be a struct whose fields may contain "url" tags.
+// addOptions adds the parameters in opt as URL query parameters to s, keeping
+// any query parameters s already has.  opt must be a struct whose fields may
+// contain "url" tags.
 func addOptions(s string, opt interface{}) (string, error) {
 	v := reflect.ValueOf(opt)
 	if v.Kind() == reflect.Ptr && v.IsNil() {
@@ -276,6 +561,11 @@ func addOptions(s string, opt interface{}) (string, error) {
 		return s, err
 	}
 
-	u.RawQuery = qs.Encode()
+	q := u.Query()
+	for k, v := range qs {
+		q[k] = v
+	}
+
+	u.RawQuery = q.Encode()
 	return u.String(), nil
 }
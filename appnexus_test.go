@@ -2,6 +2,7 @@ package appnexus
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -53,7 +54,7 @@ func TestNewRequest(t *testing.T) {
 
 	inURL, outURL := "/foo", "http://sand.api.appnexus.com/foo"
 	inBody, outBody := &User{FirstName: "Andy"}, `{"first_name":"Andy"}`+"\n"
-	req, _ := c.newRequest("GET", inURL, inBody)
+	req, _ := c.newRequest(context.Background(), "GET", inURL, inBody)
 
 	// test that relative URL was expanded
 	if actual, expected := req.URL.String(), outURL; actual != expected {
@@ -86,41 +87,218 @@ func TestCheckResponse(t *testing.T) {
 		t.Errorf("Expected error response")
 	}
 
-	expected := errors.New("AppNexus:checkResponse [SYNTAX]: invalid service")
-	if !reflect.DeepEqual(err, expected) {
-		t.Errorf("Error = %#v, expected %#v", err, expected)
+	if actual, expected := err.Error(), "AppNexus:checkResponse [SYNTAX]: invalid service"; actual != expected {
+		t.Errorf("Error = %v, expected %v", actual, expected)
+	}
+
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Error = %#v, expected an *Error", err)
+	}
+
+	if apiErr.ID != "SYNTAX" || apiErr.StatusCode != http.StatusOK {
+		t.Errorf("Error = %+v, expected SYNTAX with status %d", apiErr, http.StatusOK)
+	}
+
+	if !errors.Is(err, ErrSyntax) || errors.Is(err, ErrNoAuth) {
+		t.Errorf("Error = %v, expected to match only ErrSyntax", err)
 	}
 }
 
+func TestCheckResponse_HTTPStatus(t *testing.T) {
+
+	c, _ := NewClient("http://sand.api.appnexus.com/")
+	data := []byte(`{"response":{"error_id":"SYSTEM","error_code":"RATE_EXCEEDED","error":"too many requests","service":"segment","method":"get"}}`)
+
+	res := &http.Response{
+		Request:    &http.Request{},
+		Status:     "429 Too Many Requests",
+		StatusCode: http.StatusTooManyRequests,
+		Body:       ioutil.NopCloser(bytes.NewReader(data)),
+	}
+
+	_, err := c.checkResponse(res, data)
+
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Error = %#v, expected an *Error", err)
+	}
+
+	expected := Error{
+		StatusCode: http.StatusTooManyRequests,
+		ID:         "SYSTEM",
+		Code:       "RATE_EXCEEDED",
+		Message:    "too many requests",
+		Service:    "segment",
+		Method:     "get",
+	}
+	apiErr.Response = nil
+	if !reflect.DeepEqual(*apiErr, expected) {
+		t.Errorf("Error = %+v, expected %+v", *apiErr, expected)
+	}
+
+	if !errors.Is(err, ErrRateLimited) || !errors.Is(err, ErrSystem) {
+		t.Errorf("Error = %v, expected to match ErrRateLimited and ErrSystem", err)
+	}
+
+	res.Status, res.StatusCode = "404 Not Found", http.StatusNotFound
+	_, err = c.checkResponse(res, []byte("not json"))
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Error = %v, expected to match ErrNotFound", err)
+	}
+}
+
+// waited returns the rounded number of seconds spent waiting on the rate limit
+func waited(c *Client, method string) string {
+	d, _ := c.waitForRateLimit(context.Background(), method)
+	return fmt.Sprintf("%.0f", d.Seconds())
+}
+
 func TestWaitForRateLimit(t *testing.T) {
 
 	c, _ := NewClient("http://sand.api.appnexus.com/")
-	c.Rate.Time = time.Now()
-	c.Rate.ReadLimit = 100
-	c.Rate.ReadLimitSeconds = 2
-	c.Rate.Reads = 99
-	c.Rate.WriteLimit = 100
-	c.Rate.WriteLimitSeconds = 2
-	c.Rate.Writes = 0
-
-	if actual, expected := fmt.Sprintf("%.0f", c.waitForRateLimit("GET").Seconds()), "0"; actual != expected {
+	c.setRate(Rate{
+		Time:              time.Now(),
+		ReadLimit:         2,
+		ReadLimitSeconds:  2,
+		Reads:             1,
+		WriteLimit:        100,
+		WriteLimitSeconds: 2,
+		Writes:            0,
+	})
+
+	if actual, expected := waited(c, "GET"), "0"; actual != expected {
 		t.Errorf("Waited %v for read rate limit, expected %v", actual, expected)
 	}
 
-	c.Rate.Reads = 100
-	if actual, expected := fmt.Sprintf("%.0f", c.waitForRateLimit("GET").Seconds()), "2"; actual != expected {
+	if actual, expected := waited(c, "GET"), "1"; actual != expected {
 		t.Errorf("Waited %v for read rate limit, expected %v", actual, expected)
 	}
 
-	if actual, expected := fmt.Sprintf("%.0f", c.waitForRateLimit("POST").Seconds()), "0"; actual != expected {
+	if actual, expected := waited(c, "POST"), "0"; actual != expected {
 		t.Errorf("Waited %v for write rate limit, expected %v", actual, expected)
 	}
 
-	if actual, expected := fmt.Sprintf("%.0f", c.waitForRateLimit("PUT").Seconds()), "0"; actual != expected {
+	if actual, expected := waited(c, "PUT"), "0"; actual != expected {
 		t.Errorf("Waited %v for write rate limit, expected %v", actual, expected)
 	}
 
-	if actual, expected := fmt.Sprintf("%.0f", c.waitForRateLimit("DELETE").Seconds()), "0"; actual != expected {
+	if actual, expected := waited(c, "DELETE"), "0"; actual != expected {
 		t.Errorf("Waited %v for write rate limit, expected %v", actual, expected)
 	}
 }
+
+func TestWaitForRateLimit_ContextCancelled(t *testing.T) {
+
+	c, _ := NewClient("http://sand.api.appnexus.com/")
+	c.setRate(Rate{
+		Time:             time.Now(),
+		ReadLimit:        100,
+		ReadLimitSeconds: 60,
+		Reads:            100,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := c.waitForRateLimit(ctx, "GET")
+	if err != context.DeadlineExceeded {
+		t.Errorf("waitForRateLimit returned %v, expected %v", err, context.DeadlineExceeded)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("waitForRateLimit blocked for %v after the context deadline", elapsed)
+	}
+}
+
+func TestCheckResponse_DebugInfo(t *testing.T) {
+
+	c, _ := NewClient("http://sand.api.appnexus.com/")
+	data := []byte(`{"response":{"status":"OK","dbg_info":{
+		"instance":"64.bm-hbapi.prod.nym2","slave_hit":true,"db":"10.3.129.86",
+		"awesomesauce_cache_used":false,"count_cache_used":true,"warnings":["deprecated field"],
+		"time":104.5,"start_microtime":1452799276.6233,"version":"1.16.464","slave_lag":0,
+		"member_last_modified_age":10385,"output_term":"segments",
+		"reads":3,"read_limit":100,"read_limit_seconds":60,"writes":1,"write_limit":60,"write_limit_seconds":60,
+		"parent_dbg_info":{"instance":"parent.prod.nym2","time":2.5}}}}`)
+
+	res := &http.Response{
+		Request:    &http.Request{},
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(bytes.NewReader(data)),
+	}
+
+	resp, err := c.checkResponse(res, data)
+	if err != nil {
+		t.Fatalf("checkResponse returned error: %v", err)
+	}
+
+	d := resp.Obj.DebugInfo
+	if d.Instance != "64.bm-hbapi.prod.nym2" || !d.SlaveHit || d.DB != "10.3.129.86" || !d.CountCacheUsed ||
+		d.ProcessingTime != 104.5 || d.Version != "1.16.464" || d.OutputTerm != "segments" ||
+		d.MemberLastModifiedAge != 10385 || len(d.Warnings) != 1 {
+		t.Errorf("DebugInfo is %+v, expected every dbg_info field decoded", d)
+	}
+
+	if d.ParentDebugInfo == nil || d.ParentDebugInfo.Instance != "parent.prod.nym2" {
+		t.Errorf("ParentDebugInfo is %+v, expected parent.prod.nym2", d.ParentDebugInfo)
+	}
+
+	if resp.Obj.Rate.Reads != 3 || resp.Obj.Rate.WriteLimit != 60 {
+		t.Errorf("Rate is %+v, expected 3 reads and a write limit of 60", resp.Obj.Rate)
+	}
+
+	if actual := c.CurrentRate(); actual.ReadLimit != 100 || actual.Time.IsZero() {
+		t.Errorf("CurrentRate is %+v, expected a read limit of 100 seen just now", actual)
+	}
+}
+
+func TestAddOptions_KeepsQuery(t *testing.T) {
+	actual, err := addOptions("advertiser?member_id=1", &ListOptions{StartElement: 2, NumElements: 10})
+	if err != nil {
+		t.Fatalf("addOptions returned error: %v", err)
+	}
+
+	if expected := "advertiser?member_id=1&num_elements=10&start_element=2"; actual != expected {
+		t.Errorf("addOptions returned %s, expected %s", actual, expected)
+	}
+}
+
+func TestLogin_Token(t *testing.T) {
+	setup()
+	defer teardown()
+
+	responses := []func(w http.ResponseWriter){
+		func(w http.ResponseWriter) {
+			fmt.Fprint(w, `{"response":{"status":"OK","token":"body-token"}}`)
+		},
+		func(w http.ResponseWriter) {
+			http.SetCookie(w, &http.Cookie{Name: "authn", Value: "cookie-token"})
+			fmt.Fprint(w, `{"response":{"status":"OK"}}`)
+		},
+		func(w http.ResponseWriter) {
+			fmt.Fprint(w, `{"response":{"status":"OK"}}`)
+		},
+	}
+
+	logins := 0
+	mux.HandleFunc("/auth", func(w http.ResponseWriter, r *http.Request) {
+		responses[logins](w)
+		logins++
+	})
+
+	for _, expected := range []string{"body-token", "cookie-token"} {
+		if err := client.Login("user", "password"); err != nil {
+			t.Fatalf("Login returned error: %v", err)
+		}
+
+		if actual := client.loadToken(context.Background()); actual != expected {
+			t.Errorf("Login saved token %q, expected %q", actual, expected)
+		}
+	}
+
+	if err := client.Login("user", "password"); err == nil {
+		t.Error("Login succeeded without a token in the response")
+	}
+}
@@ -0,0 +1,255 @@
+package appnexus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// BatchSegmentService handles all requests to the batch segment service API,
+// which adds users to segments in bulk from an uploaded file
+type BatchSegmentService struct {
+	*Response
+	client *Client
+}
+
+// BatchSegmentPhaseCompleted is the phase of a job that has finished
+// processing its file
+const BatchSegmentPhaseCompleted = "completed"
+
+// BatchSegmentJob is a batch segment upload job and its processing status
+type BatchSegmentJob struct {
+	ID                  int     `json:"id,omitempty"`
+	JobID               string  `json:"job_id,omitempty"`
+	MemberID            int     `json:"member_id,omitempty"`
+	UploadURL           string  `json:"upload_url,omitempty"`
+	Phase               string  `json:"phase,omitempty"`
+	StartTime           string  `json:"start_time,omitempty"`
+	UploadedTime        string  `json:"uploaded_time,omitempty"`
+	ValidatedTime       string  `json:"validated_time,omitempty"`
+	CompletedTime       string  `json:"completed_time,omitempty"`
+	CreatedOn           string  `json:"created_on,omitempty"`
+	LastModified        string  `json:"last_modified,omitempty"`
+	ErrorCode           string  `json:"error_code,omitempty"`
+	TimeToProcess       float64 `json:"time_to_process,omitempty"`
+	PercentComplete     float64 `json:"percent_complete,omitempty"`
+	UploadSize          int     `json:"upload_size,omitempty"`
+	NumValid            int     `json:"num_valid,omitempty"`
+	NumValidUser        int     `json:"num_valid_user,omitempty"`
+	NumInvalidFormat    int     `json:"num_invalid_format,omitempty"`
+	NumInvalidUser      int     `json:"num_invalid_user,omitempty"`
+	NumInvalidSegment   int     `json:"num_invalid_segment,omitempty"`
+	NumInvalidTimestamp int     `json:"num_invalid_timestamp,omitempty"`
+	NumUnauthSegment    int     `json:"num_unauth_segment,omitempty"`
+	NumPastExpiration   int     `json:"num_past_expiration,omitempty"`
+	NumInactiveSegment  int     `json:"num_inactive_segment,omitempty"`
+	NumOtherError       int     `json:"num_other_error,omitempty"`
+	ErrorLogLines       string  `json:"error_log_lines,omitempty"`
+	SegmentLogLines     string  `json:"segment_log_lines,omitempty"`
+}
+
+// batchSegmentJobs decodes batch_segment_upload_job, which AppNexus returns
+// as a single job when one is created but as an array when one is looked up
+type batchSegmentJobs []BatchSegmentJob
+
+func (j *batchSegmentJobs) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	if bytes.HasPrefix(data, []byte("[")) {
+		return json.Unmarshal(data, (*[]BatchSegmentJob)(j))
+	}
+
+	var job BatchSegmentJob
+	if err := json.Unmarshal(data, &job); err != nil {
+		return err
+	}
+
+	*j = batchSegmentJobs{job}
+	return nil
+}
+
+type batchSegmentResponse struct {
+	*http.Response
+	Obj struct {
+		BatchSegmentJob  batchSegmentJobs  `json:"batch_segment_upload_job"`
+		BatchSegmentJobs []BatchSegmentJob `json:"batch_segment_upload_jobs"`
+		Error            string            `json:"error"`
+		Status           string            `json:"status"`
+		Service          string            `json:"service"`
+		Rate             Rate              `json:"dbg_info"`
+	} `json:"response"`
+}
+
+// job returns the first job in the response, or an empty one if there is
+// none
+func (r *batchSegmentResponse) job() *BatchSegmentJob {
+	switch {
+	case len(r.Obj.BatchSegmentJob) > 0:
+		return &r.Obj.BatchSegmentJob[0]
+	case len(r.Obj.BatchSegmentJobs) > 0:
+		return &r.Obj.BatchSegmentJobs[0]
+	}
+
+	return &BatchSegmentJob{}
+}
+
+// Completed reports whether AppNexus has finished processing the job
+func (j *BatchSegmentJob) Completed() bool {
+	return j.Phase == BatchSegmentPhaseCompleted
+}
+
+// ErrorLog returns the lines of the job's error log, one per rejected
+// record or error class
+func (j *BatchSegmentJob) ErrorLog() []string {
+	var lines []string
+	for _, line := range strings.Split(j.ErrorLogLines, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	return lines
+}
+
+// CreateJob requests a new upload job, whose UploadURL the file is sent to
+func (s *BatchSegmentService) CreateJob(memberID int) (*BatchSegmentJob, error) {
+	return s.CreateJobContext(context.Background(), memberID)
+}
+
+// CreateJobContext is like CreateJob but honours cancellation and deadlines
+// on ctx
+func (s *BatchSegmentService) CreateJobContext(ctx context.Context, memberID int) (*BatchSegmentJob, error) {
+
+	req, err := s.client.newRequest(ctx, "POST", fmt.Sprintf("batch-segment?member_id=%d", memberID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &batchSegmentResponse{}
+	_, err = s.client.do(req, r)
+	if err != nil {
+		return nil, err
+	}
+
+	job := r.job()
+	if job.UploadURL == "" {
+		return nil, errors.New("Batch segment job was created without an upload URL")
+	}
+
+	return job, nil
+}
+
+// Upload streams a segment file, such as one written by BatchSegmentWriter,
+// to the job's upload URL. Files that can be read at an offset, like
+// *os.File, are replayed when the upload is rate limited or has to be sent
+// again after a new login. As a POST, it is not retried after a network
+// error or 5xx response unless the client's RetryPolicy sets
+// RetryNonIdempotent.
+func (s *BatchSegmentService) Upload(job *BatchSegmentJob, file io.Reader) error {
+	return s.UploadContext(context.Background(), job, file)
+}
+
+// UploadContext is like Upload but honours cancellation and deadlines on ctx
+func (s *BatchSegmentService) UploadContext(ctx context.Context, job *BatchSegmentJob, file io.Reader) error {
+
+	if _, err := url.Parse(job.UploadURL); err != nil || job.UploadURL == "" {
+		return errors.New("Upload requires a batch segment job with an upload URL")
+	}
+
+	req, err := s.client.newRequest(ctx, "POST", job.UploadURL, file)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	_, err = s.client.do(req, nil)
+	return err
+}
+
+// GetJob fetches the status of an upload job
+func (s *BatchSegmentService) GetJob(memberID int, jobID string) (*BatchSegmentJob, error) {
+	return s.GetJobContext(context.Background(), memberID, jobID)
+}
+
+// GetJobContext is like GetJob but honours cancellation and deadlines on ctx
+func (s *BatchSegmentService) GetJobContext(ctx context.Context, memberID int, jobID string) (*BatchSegmentJob, error) {
+
+	path := fmt.Sprintf("batch-segment?member_id=%d&job_id=%s", memberID, url.QueryEscape(jobID))
+	req, err := s.client.newRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &batchSegmentResponse{}
+	_, err = s.client.do(req, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.job(), nil
+}
+
+// Wait polls the status of an upload job every interval until AppNexus has
+// finished processing it, or ctx is done. The interval must be positive.
+// Jobs that AppNexus does not know or that fail with an error code end the
+// wait with an error.
+func (s *BatchSegmentService) Wait(ctx context.Context, memberID int, jobID string, interval time.Duration) (*BatchSegmentJob, error) {
+	if interval <= 0 {
+		return nil, errors.New("Wait requires a positive polling interval")
+	}
+
+	for {
+		job, err := s.GetJobContext(ctx, memberID, jobID)
+		if err != nil {
+			return nil, err
+		}
+
+		if job.JobID == "" {
+			return nil, fmt.Errorf("Batch segment job %q was not found", jobID)
+		}
+
+		if job.ErrorCode != "" {
+			return job, fmt.Errorf("Batch segment job %q failed: %s", jobID, job.ErrorCode)
+		}
+
+		if job.Completed() {
+			return job, nil
+		}
+
+		if err := sleepContext(ctx, interval); err != nil {
+			return job, err
+		}
+	}
+}
+
+// UploadFile runs a whole batch segment upload: it creates a job, uploads
+// the file to it and waits for AppNexus to process it, polling every
+// interval. Check the returned job's counters and ErrorLog for records that
+// were rejected.
+func (s *BatchSegmentService) UploadFile(ctx context.Context, memberID int, file io.Reader, interval time.Duration) (*BatchSegmentJob, error) {
+	if interval <= 0 {
+		return nil, errors.New("UploadFile requires a positive polling interval")
+	}
+
+	job, err := s.CreateJobContext(ctx, memberID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.UploadContext(ctx, job, file); err != nil {
+		return job, err
+	}
+
+	return s.Wait(ctx, memberID, job.JobID, interval)
+}
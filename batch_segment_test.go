@@ -0,0 +1,232 @@
+package appnexus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBatchSegmentService_UploadFile(t *testing.T) {
+	setup()
+	defer teardown()
+
+	polls := 0
+	mux.HandleFunc("/batch-segment", func(w http.ResponseWriter, r *http.Request) {
+		if actual, expected := r.URL.Query().Get("member_id"), "1"; actual != expected {
+			t.Errorf("member_id is %v, expected %v", actual, expected)
+		}
+
+		switch r.Method {
+		case "POST":
+			fmt.Fprintf(w, `{"response":{"status":"OK","batch_segment_upload_job":{
+				"job_id":"job1","member_id":1,"upload_url":"%s/upload/job1"}}}`, server.URL)
+		case "GET":
+			if actual, expected := r.URL.Query().Get("job_id"), "job1"; actual != expected {
+				t.Errorf("job_id is %v, expected %v", actual, expected)
+			}
+
+			phase := "processing"
+			if polls++; polls > 1 {
+				phase = "completed"
+			}
+			fmt.Fprintf(w, `{"response":{"status":"OK","batch_segment_upload_job":{
+				"job_id":"job1","phase":"%s","num_valid":2,"num_invalid_format":1,
+				"error_log_lines":"\ninvalid_format-3\n\n"}}}`, phase)
+		}
+	})
+
+	var uploaded string
+	mux.HandleFunc("/upload/job1", func(w http.ResponseWriter, r *http.Request) {
+		if actual, expected := r.Header.Get("Content-Type"), "application/octet-stream"; actual != expected {
+			t.Errorf("Content-Type is %v, expected %v", actual, expected)
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		uploaded = string(body)
+		fmt.Fprint(w, `{"response":{"status":"OK"}}`)
+	})
+
+	file := "1234;5:0\n5678;5:0\nbad line\n"
+	job, err := client.BatchSegments.UploadFile(context.Background(), 1, strings.NewReader(file), time.Millisecond)
+	if err != nil {
+		t.Fatalf("BatchSegments.UploadFile returned error: %v", err)
+	}
+
+	if uploaded != file {
+		t.Errorf("uploaded %q, expected %q", uploaded, file)
+	}
+
+	if !job.Completed() || job.NumValid != 2 || job.NumInvalidFormat != 1 || polls != 2 {
+		t.Errorf("BatchSegments.UploadFile returned %+v after %d polls, expected a completed job after 2", job, polls)
+	}
+
+	if actual := fmt.Sprint(job.ErrorLog()); actual != "[invalid_format-3]" {
+		t.Errorf("ErrorLog is %v, expected [invalid_format-3]", actual)
+	}
+}
+
+func TestBatchSegmentService_WaitCancelled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/batch-segment", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"response":{"status":"OK","batch_segment_upload_job":{"job_id":"job1","phase":"validating"}}}`)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	job, err := client.BatchSegments.Wait(ctx, 1, "job1", time.Hour)
+	if err != context.DeadlineExceeded {
+		t.Errorf("BatchSegments.Wait returned %v, expected %v", err, context.DeadlineExceeded)
+	}
+
+	if job == nil || job.Phase != "validating" {
+		t.Errorf("BatchSegments.Wait returned %+v, expected the last status seen", job)
+	}
+}
+
+func TestBatchSegmentService_GetJobArray(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/batch-segment", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"response":{"status":"OK","batch_segment_upload_job":[{"job_id":"job1","phase":"completed","num_valid":2}]}}`)
+	})
+
+	job, err := client.BatchSegments.GetJob(1, "job1")
+	if err != nil {
+		t.Fatalf("BatchSegments.GetJob returned error: %v", err)
+	}
+
+	if job.JobID != "job1" || !job.Completed() || job.NumValid != 2 {
+		t.Errorf("BatchSegments.GetJob returned %+v", job)
+	}
+}
+
+func TestBatchSegmentService_WaitStops(t *testing.T) {
+	setup()
+	defer teardown()
+
+	polls := 0
+	mux.HandleFunc("/batch-segment", func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		switch r.URL.Query().Get("job_id") {
+		case "failed":
+			fmt.Fprint(w, `{"response":{"status":"OK","batch_segment_upload_job":[{"job_id":"failed","phase":"validating","error_code":"invalid_file"}]}}`)
+		default:
+			fmt.Fprint(w, `{"response":{"status":"OK","batch_segment_upload_job":[]}}`)
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if job, err := client.BatchSegments.Wait(ctx, 1, "unknown", time.Millisecond); err == nil || job != nil {
+		t.Errorf("BatchSegments.Wait returned %+v (%v) for an unknown job, expected an error", job, err)
+	}
+
+	job, err := client.BatchSegments.Wait(ctx, 1, "failed", time.Millisecond)
+	if err == nil || errors.Is(err, context.DeadlineExceeded) || job == nil || job.ErrorCode != "invalid_file" {
+		t.Errorf("BatchSegments.Wait returned %+v (%v) for a failed job, expected its error code", job, err)
+	}
+
+	if polls != 2 {
+		t.Errorf("BatchSegments.Wait polled %d times, expected to stop after the first poll of each job", polls)
+	}
+}
+
+func TestBatchSegmentService_WaitInterval(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/batch-segment", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("BatchSegments sent %s %s despite a zero polling interval", r.Method, r.URL)
+	})
+
+	if _, err := client.BatchSegments.Wait(context.Background(), 1, "job1", 0); err == nil {
+		t.Error("BatchSegments.Wait accepted a zero polling interval")
+	}
+
+	if _, err := client.BatchSegments.UploadFile(context.Background(), 1, strings.NewReader(""), -time.Second); err == nil {
+		t.Error("BatchSegments.UploadFile accepted a negative polling interval")
+	}
+}
+
+func TestBatchSegmentService_UploadReplaysFile(t *testing.T) {
+	setup()
+	defer teardown()
+	client.RetryPolicy = fastRetryPolicy
+
+	var bodies []string
+	mux.HandleFunc("/upload/job1", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if bodies = append(bodies, string(body)); len(bodies) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		fmt.Fprint(w, `{"response":{"status":"OK"}}`)
+	})
+
+	f, err := os.CreateTemp(t.TempDir(), "segments")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	fmt.Fprint(f, "1234;5:0\n")
+	f.Seek(0, io.SeekStart)
+
+	job := &BatchSegmentJob{JobID: "job1", UploadURL: server.URL + "/upload/job1"}
+	if err := client.BatchSegments.Upload(job, f); err != nil {
+		t.Fatalf("BatchSegments.Upload returned error: %v", err)
+	}
+
+	if fmt.Sprint(bodies) != "[1234;5:0\n 1234;5:0\n]" {
+		t.Errorf("uploaded %q, expected the file twice", bodies)
+	}
+}
+
+func TestBatchSegmentService_UploadFileWithTrafficLogging(t *testing.T) {
+	setup()
+	defer teardown()
+
+	logs := new(strings.Builder)
+	client, _ = NewClient(server.URL, WithTrafficLogging(slog.New(slog.NewJSONHandler(logs, &slog.HandlerOptions{Level: slog.LevelDebug}))))
+
+	var uploaded string
+	mux.HandleFunc("/upload/job1", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		uploaded = string(body)
+		fmt.Fprint(w, `{"response":{"status":"OK"}}`)
+	})
+
+	f, err := os.CreateTemp(t.TempDir(), "segments")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	fmt.Fprint(f, "1234;5:0\n5678;6:0\n")
+	f.Seek(0, io.SeekStart)
+
+	job := &BatchSegmentJob{JobID: "job1", UploadURL: server.URL + "/upload/job1"}
+	if err := client.BatchSegments.Upload(job, f); err != nil {
+		t.Fatalf("BatchSegments.Upload returned error: %v", err)
+	}
+
+	if uploaded != "1234;5:0\n5678;6:0\n" {
+		t.Errorf("uploaded %q, expected the whole file", uploaded)
+	}
+
+	if !strings.Contains(logs.String(), "/upload/job1") {
+		t.Errorf("logged %q, expected the upload", logs.String())
+	}
+}
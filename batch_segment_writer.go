@@ -0,0 +1,326 @@
+package appnexus
+
+import (
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// SegmentField is a field of a segment in a batch segment upload file
+type SegmentField string
+
+// Fields a segment can be written with, in the order given by
+// BatchSegmentFormat.SegmentFields
+const (
+	SegmentFieldID         SegmentField = "seg_id"
+	SegmentFieldCode       SegmentField = "seg_code"
+	SegmentFieldMemberID   SegmentField = "member_id"
+	SegmentFieldExpiration SegmentField = "expiration"
+	SegmentFieldValue      SegmentField = "value"
+	SegmentFieldTimestamp  SegmentField = "timestamp"
+)
+
+// UserIDType is the kind of user ID a batch segment upload file is keyed by
+type UserIDType string
+
+// User ID types: AppNexus user IDs, or device IDs given with their type
+const (
+	UserIDTypeUID         UserIDType = "uid"
+	UserIDTypeIDFA        UserIDType = "idfa"
+	UserIDTypeAAID        UserIDType = "aaid"
+	UserIDTypeWindowsAdID UserIDType = "windowsadid"
+	UserIDTypeSHA1UDID    UserIDType = "sha1udid"
+	UserIDTypeMD5UDID     UserIDType = "md5udid"
+)
+
+// BatchSegmentFormat describes the layout of a batch segment upload file.
+// Each line holds one user:
+//
+//	USER_ID [SEP_5 DEVICE_TYPE] SEP_1 SEGMENT SEP_3 SEGMENT ... [SEP_4 SEGMENT SEP_3 SEGMENT ...]
+//
+// where each SEGMENT is its SegmentFields joined by SEP_2, and the segments
+// after SEP_4 are the ones the user is removed from.
+type BatchSegmentFormat struct {
+	Sep1          string
+	Sep2          string
+	Sep3          string
+	Sep4          string
+	Sep5          string
+	SegmentFields []SegmentField
+	UserIDType    UserIDType
+}
+
+// DefaultBatchSegmentFormat is the AppNexus default file layout, with
+// segments given by ID and expiration:
+//
+//	2837465102938475612;12345:1440,67890:0
+var DefaultBatchSegmentFormat = BatchSegmentFormat{
+	Sep1:          ";",
+	Sep2:          ":",
+	Sep3:          ",",
+	Sep4:          "^",
+	Sep5:          "#",
+	SegmentFields: []SegmentField{SegmentFieldID, SegmentFieldExpiration},
+	UserIDType:    UserIDTypeUID,
+}
+
+// SegmentMembership puts a user in a segment, given either by ID or by code
+// and member ID. Expiration is in minutes, where 0 uses the segment's
+// default; Value is an optional integer stored with the membership.
+type SegmentMembership struct {
+	SegmentID  int
+	Code       string
+	MemberID   int
+	Expiration int
+	Value      int
+	Timestamp  int64
+	Remove     bool
+}
+
+// Membership returns a SegmentMembership of the user in s
+func (s Segment) Membership(expiration, value int) SegmentMembership {
+	return SegmentMembership{
+		SegmentID:  s.ID,
+		Code:       s.Code,
+		MemberID:   s.MemberID,
+		Expiration: expiration,
+		Value:      value,
+	}
+}
+
+// deviceIDPattern matches the UUID form of IDFA and AAID device IDs
+var deviceIDPattern = regexp.MustCompile(`^[0-9A-Fa-f]{8}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{12}$`)
+
+// validate checks that the format can be written unambiguously
+func (f BatchSegmentFormat) validate() error {
+	seps := []string{f.Sep1, f.Sep2, f.Sep3, f.Sep4, f.Sep5}
+	for i, sep := range seps {
+		if sep == "" || strings.ContainsAny(sep, "\r\n") {
+			return fmt.Errorf("Batch segment format separator %d must be set and cannot be a newline", i+1)
+		}
+
+		for j := 0; j < i; j++ {
+			if seps[j] == sep {
+				return fmt.Errorf("Batch segment format separators %d and %d are both %q", j+1, i+1, sep)
+			}
+		}
+	}
+
+	if len(f.SegmentFields) == 0 {
+		return errors.New("Batch segment format requires at least one segment field")
+	}
+
+	byID, byCode, byMember := false, false, false
+	for _, field := range f.SegmentFields {
+		switch field {
+		case SegmentFieldID:
+			byID = true
+		case SegmentFieldCode:
+			byCode = true
+		case SegmentFieldMemberID:
+			byMember = true
+		case SegmentFieldExpiration, SegmentFieldValue, SegmentFieldTimestamp:
+		default:
+			return fmt.Errorf("Unknown batch segment field %q", field)
+		}
+	}
+
+	if byID == byCode {
+		return errors.New("Batch segment format requires either the seg_id or the seg_code field")
+	}
+
+	if byCode && !byMember {
+		return errors.New("Batch segment format requires the member_id field with seg_code")
+	}
+
+	switch f.UserIDType {
+	case UserIDTypeUID, UserIDTypeIDFA, UserIDTypeAAID, UserIDTypeWindowsAdID, UserIDTypeSHA1UDID, UserIDTypeMD5UDID:
+	default:
+		return fmt.Errorf("Unknown batch segment user ID type %q", f.UserIDType)
+	}
+
+	return nil
+}
+
+// BatchSegmentWriter streams user segment memberships into a batch segment
+// upload file, one line per user
+type BatchSegmentWriter struct {
+	format BatchSegmentFormat
+	buf    *bufio.Writer
+	gz     *gzip.Writer
+	lines  int
+}
+
+// NewBatchSegmentWriter returns a writer of upload files in format f to w
+func NewBatchSegmentWriter(w io.Writer, f BatchSegmentFormat) (*BatchSegmentWriter, error) {
+	if err := f.validate(); err != nil {
+		return nil, err
+	}
+
+	return &BatchSegmentWriter{format: f, buf: bufio.NewWriter(w)}, nil
+}
+
+// NewGzipBatchSegmentWriter is like NewBatchSegmentWriter but gzip
+// compresses the file written to w
+func NewGzipBatchSegmentWriter(w io.Writer, f BatchSegmentFormat) (*BatchSegmentWriter, error) {
+	if err := f.validate(); err != nil {
+		return nil, err
+	}
+
+	gz := gzip.NewWriter(w)
+	return &BatchSegmentWriter{format: f, buf: bufio.NewWriter(gz), gz: gz}, nil
+}
+
+// Write adds the line for a user and their segment memberships. Nothing is
+// written if the record is invalid.
+func (w *BatchSegmentWriter) Write(userID string, segments []SegmentMembership) error {
+	f := w.format
+
+	if err := w.validateUserID(userID); err != nil {
+		return err
+	}
+
+	if len(segments) == 0 {
+		return fmt.Errorf("User %s has no segments to write", userID)
+	}
+
+	var add, remove []string
+	for _, m := range segments {
+		seg, err := w.segment(m)
+		if err != nil {
+			return fmt.Errorf("User %s: %w", userID, err)
+		}
+
+		if m.Remove {
+			remove = append(remove, seg)
+		} else {
+			add = append(add, seg)
+		}
+	}
+
+	line := userID
+	if f.UserIDType != UserIDTypeUID {
+		line += f.Sep5 + string(f.UserIDType)
+	}
+
+	line += f.Sep1 + strings.Join(add, f.Sep3)
+	if len(remove) > 0 {
+		line += f.Sep4 + strings.Join(remove, f.Sep3)
+	}
+
+	if _, err := w.buf.WriteString(line + "\n"); err != nil {
+		return err
+	}
+
+	w.lines++
+	return nil
+}
+
+// validateUserID checks the user ID matches the format's user ID type
+func (w *BatchSegmentWriter) validateUserID(userID string) error {
+	switch w.format.UserIDType {
+	case UserIDTypeUID:
+		if _, err := strconv.ParseUint(userID, 10, 64); err != nil {
+			return fmt.Errorf("Invalid AppNexus user ID %q", userID)
+		}
+	case UserIDTypeIDFA, UserIDTypeAAID:
+		if !deviceIDPattern.MatchString(userID) {
+			return fmt.Errorf("Invalid %s device ID %q", w.format.UserIDType, userID)
+		}
+	default:
+		if userID == "" || w.containsSeparator(userID) {
+			return fmt.Errorf("Invalid %s device ID %q", w.format.UserIDType, userID)
+		}
+	}
+
+	return nil
+}
+
+// segment formats a single segment membership
+func (w *BatchSegmentWriter) segment(m SegmentMembership) (string, error) {
+	fields := make([]string, len(w.format.SegmentFields))
+
+	for i, field := range w.format.SegmentFields {
+		switch field {
+		case SegmentFieldID:
+			if m.SegmentID < 1 {
+				return "", errors.New("segment membership requires a segment ID")
+			}
+			fields[i] = strconv.Itoa(m.SegmentID)
+		case SegmentFieldCode:
+			if m.Code == "" || w.containsSeparator(m.Code) {
+				return "", fmt.Errorf("invalid segment code %q", m.Code)
+			}
+			fields[i] = m.Code
+		case SegmentFieldMemberID:
+			if m.MemberID < 1 {
+				return "", errors.New("segment membership requires a member ID")
+			}
+			fields[i] = strconv.Itoa(m.MemberID)
+		case SegmentFieldExpiration:
+			if m.Expiration < 0 {
+				return "", fmt.Errorf("invalid segment expiration %d", m.Expiration)
+			}
+			fields[i] = strconv.Itoa(m.Expiration)
+		case SegmentFieldValue:
+			if m.Value < 0 {
+				return "", fmt.Errorf("invalid segment value %d", m.Value)
+			}
+			fields[i] = strconv.Itoa(m.Value)
+		case SegmentFieldTimestamp:
+			fields[i] = strconv.FormatInt(m.Timestamp, 10)
+		}
+	}
+
+	return strings.Join(fields, w.format.Sep2), nil
+}
+
+// containsSeparator reports whether s would be mistaken for part of the
+// file's structure
+func (w *BatchSegmentWriter) containsSeparator(s string) bool {
+	f := w.format
+	for _, sep := range []string{f.Sep1, f.Sep2, f.Sep3, f.Sep4, f.Sep5, "\n", "\r"} {
+		if strings.Contains(s, sep) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Lines returns the number of user lines written
+func (w *BatchSegmentWriter) Lines() int {
+	return w.lines
+}
+
+// Flush writes any buffered lines to the underlying writer
+func (w *BatchSegmentWriter) Flush() error {
+	if err := w.buf.Flush(); err != nil {
+		return err
+	}
+
+	if w.gz != nil {
+		return w.gz.Flush()
+	}
+
+	return nil
+}
+
+// Close flushes the file and, for gzip writers, writes the gzip footer. It
+// does not close the underlying writer.
+func (w *BatchSegmentWriter) Close() error {
+	if err := w.buf.Flush(); err != nil {
+		return err
+	}
+
+	if w.gz != nil {
+		return w.gz.Close()
+	}
+
+	return nil
+}
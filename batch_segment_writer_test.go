@@ -0,0 +1,106 @@
+package appnexus
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"testing"
+)
+
+func TestBatchSegmentWriter_Default(t *testing.T) {
+	buf := new(bytes.Buffer)
+	w, err := NewBatchSegmentWriter(buf, DefaultBatchSegmentFormat)
+	if err != nil {
+		t.Fatalf("NewBatchSegmentWriter returned error: %v", err)
+	}
+
+	seg := Segment{ID: 12345, MemberID: 1}
+	if err := w.Write("2837465102938475612", []SegmentMembership{
+		seg.Membership(1440, 0),
+		{SegmentID: 67890},
+		{SegmentID: 555, Remove: true},
+	}); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+
+	if err := w.Write("not-a-uid", []SegmentMembership{{SegmentID: 1}}); err == nil {
+		t.Errorf("Write expected an error for an invalid user ID")
+	}
+
+	if err := w.Write("123", []SegmentMembership{{Code: "no-id"}}); err == nil {
+		t.Errorf("Write expected an error for a segment without an ID")
+	}
+
+	w.Close()
+
+	if actual, expected := buf.String(), "2837465102938475612;12345:1440,67890:0^555:0\n"; actual != expected {
+		t.Errorf("wrote %q, expected %q", actual, expected)
+	}
+
+	if w.Lines() != 1 {
+		t.Errorf("Lines is %d, expected 1", w.Lines())
+	}
+}
+
+func TestBatchSegmentWriter_CodesAndDeviceIDs(t *testing.T) {
+	f := BatchSegmentFormat{
+		Sep1:          "|",
+		Sep2:          "-",
+		Sep3:          "+",
+		Sep4:          "!",
+		Sep5:          "#",
+		SegmentFields: []SegmentField{SegmentFieldCode, SegmentFieldMemberID, SegmentFieldValue, SegmentFieldExpiration},
+		UserIDType:    UserIDTypeIDFA,
+	}
+
+	buf := new(bytes.Buffer)
+	w, err := NewGzipBatchSegmentWriter(buf, f)
+	if err != nil {
+		t.Fatalf("NewGzipBatchSegmentWriter returned error: %v", err)
+	}
+
+	seg := Segment{Code: "cars", MemberID: 7}
+	if err := w.Write("6D92078A-8246-4BA4-AE5B-76104861E7DC", []SegmentMembership{seg.Membership(60, 3)}); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+
+	if err := w.Write("2837465102938475612", []SegmentMembership{seg.Membership(60, 3)}); err == nil {
+		t.Errorf("Write expected an error for a uid in an IDFA file")
+	}
+
+	if err := w.Write("6D92078A-8246-4BA4-AE5B-76104861E7DC", []SegmentMembership{{Code: "a-b", MemberID: 7}}); err == nil {
+		t.Errorf("Write expected an error for a code containing a separator")
+	}
+
+	w.Close()
+
+	r, err := gzip.NewReader(buf)
+	if err != nil {
+		t.Fatalf("gzip.NewReader returned error: %v", err)
+	}
+
+	data, _ := ioutil.ReadAll(r)
+	if actual, expected := string(data), "6D92078A-8246-4BA4-AE5B-76104861E7DC#idfa|cars-7-3-60\n"; actual != expected {
+		t.Errorf("wrote %q, expected %q", actual, expected)
+	}
+}
+
+func TestBatchSegmentFormat_Validate(t *testing.T) {
+	for name, modify := range map[string]func(*BatchSegmentFormat){
+		"missing separator":   func(f *BatchSegmentFormat) { f.Sep3 = "" },
+		"duplicate separator": func(f *BatchSegmentFormat) { f.Sep2 = f.Sep1 },
+		"no fields":           func(f *BatchSegmentFormat) { f.SegmentFields = nil },
+		"id and code":         func(f *BatchSegmentFormat) { f.SegmentFields = []SegmentField{SegmentFieldID, SegmentFieldCode} },
+		"code without member": func(f *BatchSegmentFormat) { f.SegmentFields = []SegmentField{SegmentFieldCode} },
+		"unknown field":       func(f *BatchSegmentFormat) { f.SegmentFields = []SegmentField{SegmentFieldID, "colour"} },
+		"unknown user type":   func(f *BatchSegmentFormat) { f.UserIDType = "cookie" },
+	} {
+		f := DefaultBatchSegmentFormat
+		f.SegmentFields = append([]SegmentField(nil), f.SegmentFields...)
+		modify(&f)
+
+		if _, err := NewBatchSegmentWriter(ioutil.Discard, f); err == nil {
+			t.Errorf("NewBatchSegmentWriter expected an error for a format with %s", name)
+		}
+	}
+}
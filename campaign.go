@@ -0,0 +1,252 @@
+package appnexus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// CampaignService handles all requests to the campaign service API
+type CampaignService struct {
+	*Response
+	client *Client
+}
+
+// Campaign CPM bid types, deciding how a campaign bids on CPM inventory
+const (
+	CPMBidTypeBase        = "base"
+	CPMBidTypeAverage     = "average"
+	CPMBidTypeClearing    = "clearing"
+	CPMBidTypePredicted   = "predicted"
+	CPMBidTypeMargin      = "margin"
+	CPMBidTypeCustomModel = "custom_model"
+	CPMBidTypeNone        = "none"
+)
+
+// Campaign bids on inventory with a set of creatives on behalf of a line
+// item
+type Campaign struct {
+	ID                 int         `json:"id,omitempty"`
+	Code               string      `json:"code,omitempty"`
+	Name               string      `json:"name,omitempty"`
+	State              string      `json:"state,omitempty"`
+	AdvertiserID       int         `json:"advertiser_id,omitempty"`
+	LineItemID         int         `json:"line_item_id,omitempty"`
+	ProfileID          int         `json:"profile_id,omitempty"`
+	StartDate          string      `json:"start_date,omitempty"`
+	EndDate            string      `json:"end_date,omitempty"`
+	Timezone           string      `json:"timezone,omitempty"`
+	BidType            string      `json:"bid_type,omitempty"`
+	CPMBidType         string      `json:"cpm_bid_type,omitempty"`
+	BaseBid            *float64    `json:"base_bid,omitempty"`
+	MinBid             *float64    `json:"min_bid,omitempty"`
+	MaxBid             *float64    `json:"max_bid,omitempty"`
+	BidMargin          *float64    `json:"bid_margin,omitempty"`
+	LifetimeBudget     *float64    `json:"lifetime_budget,omitempty"`
+	LifetimeBudgetImps *int        `json:"lifetime_budget_imps,omitempty"`
+	DailyBudget        *float64    `json:"daily_budget,omitempty"`
+	DailyBudgetImps    *int        `json:"daily_budget_imps,omitempty"`
+	EnablePacing       bool        `json:"enable_pacing,omitempty"`
+	Creatives          []ObjectRef `json:"creatives,omitempty"`
+	Labels             []Label     `json:"labels,omitempty"`
+	Comments           string      `json:"comments,omitempty"`
+	LastModified       string      `json:"last_modified,omitempty"`
+}
+
+type campaignResponse struct {
+	*http.Response
+	Obj struct {
+		Campaign  Campaign   `json:"campaign,omitempty"`
+		Campaigns []Campaign `json:"campaigns,omitempty"`
+		Error     string     `json:"error"`
+		Status    string     `json:"status"`
+		Service   string     `json:"service"`
+		Rate      Rate       `json:"dbg_info"`
+	} `json:"response"`
+}
+
+// Get a campaign from the campaign service by Advertiser ID and Campaign ID
+func (s *CampaignService) Get(advertiserID int, campaignID int) (*Campaign, error) {
+	return s.GetContext(context.Background(), advertiserID, campaignID)
+}
+
+// GetContext is like Get but honours cancellation and deadlines on ctx
+func (s *CampaignService) GetContext(ctx context.Context, advertiserID int, campaignID int) (*Campaign, error) {
+
+	path := fmt.Sprintf("campaign?advertiser_id=%d&id=%d", advertiserID, campaignID)
+	req, err := s.client.newRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &campaignResponse{}
+	_, err = s.client.do(req, r)
+	if err != nil {
+		return nil, err
+	}
+
+	campaign := &r.Obj.Campaign
+	return campaign, nil
+}
+
+// List the campaigns of an advertiser
+func (s *CampaignService) List(advertiserID int, opt *ListOptions) ([]Campaign, *Response, error) {
+	return s.ListContext(context.Background(), advertiserID, opt)
+}
+
+// ListContext is like List but honours cancellation and deadlines on ctx
+func (s *CampaignService) ListContext(ctx context.Context, advertiserID int, opt *ListOptions) ([]Campaign, *Response, error) {
+	return s.list(ctx, fmt.Sprintf("campaign?advertiser_id=%d", advertiserID), opt)
+}
+
+// ListByLineItem lists the campaigns of an advertiser's line item
+func (s *CampaignService) ListByLineItem(advertiserID int, lineItemID int, opt *ListOptions) ([]Campaign, *Response, error) {
+	return s.ListByLineItemContext(context.Background(), advertiserID, lineItemID, opt)
+}
+
+// ListByLineItemContext is like ListByLineItem but honours cancellation and
+// deadlines on ctx
+func (s *CampaignService) ListByLineItemContext(ctx context.Context, advertiserID int, lineItemID int, opt *ListOptions) ([]Campaign, *Response, error) {
+	return s.list(ctx, fmt.Sprintf("campaign?advertiser_id=%d&line_item_id=%d", advertiserID, lineItemID), opt)
+}
+
+func (s *CampaignService) list(ctx context.Context, path string, opt *ListOptions) ([]Campaign, *Response, error) {
+	u, err := addOptions(path, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.newRequest(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	campaigns := &campaignResponse{}
+	resp, err := s.client.do(req, campaigns)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return campaigns.Obj.Campaigns, resp, err
+}
+
+// Iter returns an Iterator over every campaign of the advertiser
+func (s *CampaignService) Iter(advertiserID int, opt *ListOptions) *Iterator[Campaign] {
+	return s.IterContext(context.Background(), advertiserID, opt)
+}
+
+// IterContext is like Iter but stops once ctx is done
+func (s *CampaignService) IterContext(ctx context.Context, advertiserID int, opt *ListOptions) *Iterator[Campaign] {
+	return newIterator(ctx, opt, func(ctx context.Context, opt *ListOptions) ([]Campaign, *Response, error) {
+		return s.ListContext(ctx, advertiserID, opt)
+	})
+}
+
+// ListAll fetches every campaign of the advertiser, walking through all
+// pages
+func (s *CampaignService) ListAll(advertiserID int, opt *ListOptions) ([]Campaign, error) {
+	return s.ListAllContext(context.Background(), advertiserID, opt)
+}
+
+// ListAllContext is like ListAll but honours cancellation and deadlines on
+// ctx
+func (s *CampaignService) ListAllContext(ctx context.Context, advertiserID int, opt *ListOptions) ([]Campaign, error) {
+	return listAll(ctx, opt, func(ctx context.Context, opt *ListOptions) ([]Campaign, *Response, error) {
+		return s.ListContext(ctx, advertiserID, opt)
+	})
+}
+
+// ListAllByLineItem fetches every campaign of the advertiser's line item,
+// walking through all pages
+func (s *CampaignService) ListAllByLineItem(advertiserID int, lineItemID int, opt *ListOptions) ([]Campaign, error) {
+	return s.ListAllByLineItemContext(context.Background(), advertiserID, lineItemID, opt)
+}
+
+// ListAllByLineItemContext is like ListAllByLineItem but honours
+// cancellation and deadlines on ctx
+func (s *CampaignService) ListAllByLineItemContext(ctx context.Context, advertiserID int, lineItemID int, opt *ListOptions) ([]Campaign, error) {
+	return listAll(ctx, opt, func(ctx context.Context, opt *ListOptions) ([]Campaign, *Response, error) {
+		return s.ListByLineItemContext(ctx, advertiserID, lineItemID, opt)
+	})
+}
+
+// Add a new campaign to an advertiser
+func (s *CampaignService) Add(advertiserID int, item *Campaign) (*Response, error) {
+	return s.AddContext(context.Background(), advertiserID, item)
+}
+
+// AddContext is like Add but honours cancellation and deadlines on ctx
+func (s *CampaignService) AddContext(ctx context.Context, advertiserID int, item *Campaign) (*Response, error) {
+
+	data := struct {
+		Campaign `json:"campaign"`
+	}{*item}
+
+	req, err := s.client.newRequest(ctx, "POST", fmt.Sprintf("campaign?advertiser_id=%d", advertiserID), data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Response{}
+	resp, err := s.client.do(req, result)
+	if err != nil {
+		return resp, err
+	}
+
+	item.ID = result.Obj.ID
+	return result, nil
+}
+
+// Update an existing campaign with new data
+func (s *CampaignService) Update(advertiserID int, item Campaign) (*Response, error) {
+	return s.UpdateContext(context.Background(), advertiserID, item)
+}
+
+// UpdateContext is like Update but honours cancellation and deadlines on ctx
+func (s *CampaignService) UpdateContext(ctx context.Context, advertiserID int, item Campaign) (*Response, error) {
+
+	data := struct {
+		Campaign `json:"campaign"`
+	}{item}
+
+	if item.ID < 1 {
+		return nil, errors.New("Update Campaign requires a campaign to have an ID already")
+	}
+
+	path := fmt.Sprintf("campaign?advertiser_id=%d&id=%d", advertiserID, item.ID)
+	req, err := s.client.newRequest(ctx, "PUT", path, data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Response{}
+	resp, err := s.client.do(req, result)
+	if err != nil {
+		return resp, err
+	}
+
+	return result, nil
+}
+
+// Delete the specified campaign
+func (s *CampaignService) Delete(advertiserID int, item Campaign) error {
+	return s.DeleteContext(context.Background(), advertiserID, item)
+}
+
+// DeleteContext is like Delete but honours cancellation and deadlines on ctx
+func (s *CampaignService) DeleteContext(ctx context.Context, advertiserID int, item Campaign) error {
+
+	if item.ID < 1 {
+		return errors.New("Delete Campaign requires a campaign to have an ID already")
+	}
+
+	path := fmt.Sprintf("campaign?advertiser_id=%d&id=%d", advertiserID, item.ID)
+	req, err := s.client.newRequest(ctx, "DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.do(req, nil)
+	return err
+}
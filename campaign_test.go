@@ -0,0 +1,172 @@
+package appnexus
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestCampaignService_Get(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/campaign", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("advertiser_id") != "2" || r.URL.Query().Get("id") != "21" {
+			t.Errorf("Campaigns.Get requested %s, expected advertiser_id=2&id=21", r.URL.RawQuery)
+		}
+
+		fmt.Fprint(w, `{"response":
+            {"status":"OK",
+            "campaign": {
+                "id": 21,
+                "name": "Retargeting - Desktop",
+                "line_item_id": 11,
+                "profile_id": 45,
+                "cpm_bid_type": "base",
+                "base_bid": 1.75,
+                "max_bid": null,
+                "daily_budget_imps": 50000,
+                "creatives": [{"id": 31, "name": "Banner 300x250", "state": "active"}]
+            }}}`)
+	})
+
+	actual, err := client.Campaigns.Get(2, 21)
+	if err != nil {
+		t.Fatalf("Campaigns.Get returned error: %v", err)
+	}
+
+	if actual.LineItemID != 11 || actual.ProfileID != 45 || actual.CPMBidType != CPMBidTypeBase || actual.MaxBid != nil {
+		t.Errorf("Campaigns.Get returned %+v", actual)
+	}
+
+	if actual.BaseBid == nil || *actual.BaseBid != 1.75 || actual.DailyBudgetImps == nil || *actual.DailyBudgetImps != 50000 {
+		t.Errorf("Campaigns.Get returned bid %v and daily cap %v", actual.BaseBid, actual.DailyBudgetImps)
+	}
+
+	if len(actual.Creatives) != 1 || actual.Creatives[0].ID != 31 {
+		t.Errorf("Campaigns.Get returned creatives %+v", actual.Creatives)
+	}
+}
+
+func TestCampaignService_ListByLineItem(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/campaign", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("advertiser_id") != "2" || q.Get("line_item_id") != "11" || q.Get("num_elements") != "5" {
+			t.Errorf("Campaigns.ListByLineItem requested %s", r.URL.RawQuery)
+		}
+
+		fmt.Fprint(w, `{"response":
+            {"status":"OK",
+            "count": 2,
+            "campaigns": [{"id": 21, "line_item_id": 11}, {"id": 22, "line_item_id": 11}]}}`)
+	})
+
+	actual, _, err := client.Campaigns.ListByLineItem(2, 11, &ListOptions{NumElements: 5})
+	if err != nil {
+		t.Errorf("Campaigns.ListByLineItem returned error: %v", err)
+	}
+
+	if len(actual) != 2 || actual[1].ID != 22 {
+		t.Errorf("Campaigns.ListByLineItem returned %+v", actual)
+	}
+}
+
+func TestCampaignService_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/campaign", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("advertiser_id") != "2" || r.URL.Query().Get("line_item_id") != "" {
+			t.Errorf("Campaigns.List requested %s", r.URL.RawQuery)
+		}
+
+		fmt.Fprint(w, `{"response": {"status":"OK", "count": 1, "campaigns": [{"id": 21}]}}`)
+	})
+
+	actual, _, err := client.Campaigns.List(2, nil)
+	if err != nil {
+		t.Errorf("Campaigns.List returned error: %v", err)
+	}
+
+	if len(actual) != 1 || actual[0].ID != 21 {
+		t.Errorf("Campaigns.List returned %+v", actual)
+	}
+}
+
+func TestCampaignService_Add(t *testing.T) {
+	setup()
+	defer teardown()
+
+	bid := 2.0
+	data := Campaign{
+		Name:       "Prospecting - Mobile",
+		LineItemID: 11,
+		CPMBidType: CPMBidTypeBase,
+		BaseBid:    &bid,
+		Creatives:  []ObjectRef{{ID: 31}},
+	}
+
+	mux.HandleFunc("/campaign", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Query().Get("advertiser_id") != "2" {
+			t.Errorf("Campaigns.Add sent %s %s", r.Method, r.URL)
+		}
+
+		var body struct {
+			Campaign Campaign `json:"campaign"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Campaign.BaseBid == nil || len(body.Campaign.Creatives) != 1 {
+			t.Errorf("Campaigns.Add sent %+v (%v)", body, err)
+		}
+
+		fmt.Fprint(w, `{"response": {"status":"OK", "id": 23 }}`)
+	})
+
+	actual, err := client.Campaigns.Add(2, &data)
+	if err != nil {
+		t.Errorf("Campaigns.Add returned error: %v", err)
+	}
+
+	if actual.Obj.ID != 23 || data.ID != 23 {
+		t.Errorf("Campaigns.Add returned %+v and set ID %d, expected 23", actual.Obj, data.ID)
+	}
+}
+
+func TestCampaignService_Update(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/campaign", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Query().Get("id") != "21" {
+			t.Errorf("Campaigns.Update sent %s %s", r.Method, r.URL)
+		}
+
+		fmt.Fprint(w, `{"response": {"status":"OK" }}`)
+	})
+
+	if _, err := client.Campaigns.Update(2, Campaign{ID: 21, State: "inactive"}); err != nil {
+		t.Errorf("Campaigns.Update returned error: %v", err)
+	}
+
+	if _, err := client.Campaigns.Update(2, Campaign{}); err == nil {
+		t.Error("Campaigns.Update accepted a campaign without an ID")
+	}
+}
+
+func TestCampaignService_Delete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/campaign", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" || r.URL.Query().Get("id") != "21" {
+			t.Errorf("Campaigns.Delete sent %s %s", r.Method, r.URL)
+		}
+	})
+
+	if err := client.Campaigns.Delete(2, Campaign{ID: 21}); err != nil {
+		t.Errorf("Campaigns.Delete returned error: %v", err)
+	}
+}
@@ -0,0 +1,332 @@
+package appnexus
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+)
+
+// CreativeService handles all requests to the creative and creative upload
+// service APIs
+type CreativeService struct {
+	*Response
+	client *Client
+}
+
+// Creative formats
+const (
+	CreativeFormatImage      = "image"
+	CreativeFormatRawHTML    = "raw-html"
+	CreativeFormatURLHTML    = "url-html"
+	CreativeFormatRawJS      = "raw-js"
+	CreativeFormatURLJS      = "url-js"
+	CreativeFormatIframeHTML = "iframe-html"
+	CreativeFormatText       = "text"
+	CreativeFormatVAST       = "url-vast"
+)
+
+// Creative audit statuses
+const (
+	AuditStatusNoAudit     = "no_audit"
+	AuditStatusPending     = "pending"
+	AuditStatusRejected    = "rejected"
+	AuditStatusAudited     = "audited"
+	AuditStatusUnauditable = "unauditable"
+)
+
+// TrackingPixel is a third party pixel fired with a creative
+type TrackingPixel struct {
+	ID     int    `json:"id,omitempty"`
+	URL    string `json:"url"`
+	Format string `json:"format,omitempty"`
+}
+
+// VideoWrapper points a VAST video creative at a third party VAST document
+type VideoWrapper struct {
+	URL string `json:"url"`
+}
+
+// VideoAttribute describes a video creative
+type VideoAttribute struct {
+	DurationMS int           `json:"duration_ms,omitempty"`
+	Linear     bool          `json:"is_linear,omitempty"`
+	Wrapper    *VideoWrapper `json:"wrapper,omitempty"`
+}
+
+// Creative is an ad an advertiser's campaigns serve, either hosted by
+// AppNexus (uploaded media) or served from third party content or tags
+type Creative struct {
+	ID             int             `json:"id,omitempty"`
+	Code           string          `json:"code,omitempty"`
+	Name           string          `json:"name,omitempty"`
+	State          string          `json:"state,omitempty"`
+	AdvertiserID   int             `json:"advertiser_id,omitempty"`
+	Format         string          `json:"format,omitempty"`
+	Template       *ObjectRef      `json:"template,omitempty"`
+	Width          int             `json:"width,omitempty"`
+	Height         int             `json:"height,omitempty"`
+	Content        string          `json:"content,omitempty"`
+	ContentSecure  string          `json:"content_secure,omitempty"`
+	MediaURL       string          `json:"media_url,omitempty"`
+	MediaURLSecure string          `json:"media_url_secure,omitempty"`
+	FileName       string          `json:"file_name,omitempty"`
+	ClickURL       string          `json:"click_url,omitempty"`
+	ClickTarget    string          `json:"click_target,omitempty"`
+	TrackingPixels []TrackingPixel `json:"pixels,omitempty"`
+	VideoAttribute *VideoAttribute `json:"video_attribute,omitempty"`
+	BrandID        int             `json:"brand_id,omitempty"`
+	Brand          *ObjectRef      `json:"brand,omitempty"`
+	Categories     []ObjectRef     `json:"categories,omitempty"`
+	Campaigns      []ObjectRef     `json:"campaigns,omitempty"`
+	AllowAudit     bool            `json:"allow_audit,omitempty"`
+	AllowSSLAudit  bool            `json:"allow_ssl_audit,omitempty"`
+	AuditStatus    string          `json:"audit_status,omitempty"`
+	AuditFeedback  string          `json:"audit_feedback,omitempty"`
+	SSLStatus      string          `json:"ssl_status,omitempty"`
+	IsSelfAudited  bool            `json:"is_self_audited,omitempty"`
+	IsExpired      bool            `json:"is_expired,omitempty"`
+	IsProhibited   bool            `json:"is_prohibited,omitempty"`
+	LastModified   string          `json:"last_modified,omitempty"`
+}
+
+// Audited reports whether the creative has passed the AppNexus audit
+func (c *Creative) Audited() bool {
+	return c.AuditStatus == AuditStatusAudited
+}
+
+// CreativeMedia is an asset file uploaded to AppNexus, whose MediaURL is
+// then used to create a hosted creative
+type CreativeMedia struct {
+	ID       int    `json:"id,omitempty"`
+	MediaURL string `json:"media_url,omitempty"`
+	FileName string `json:"file_name,omitempty"`
+	MimeType string `json:"mime_type,omitempty"`
+	Width    int    `json:"width,omitempty"`
+	Height   int    `json:"height,omitempty"`
+}
+
+type creativeResponse struct {
+	*http.Response
+	Obj struct {
+		Creative  Creative      `json:"creative,omitempty"`
+		Creatives []Creative    `json:"creatives,omitempty"`
+		Media     CreativeMedia `json:"creative-upload,omitempty"`
+		Error     string        `json:"error"`
+		Status    string        `json:"status"`
+		Service   string        `json:"service"`
+		Rate      Rate          `json:"dbg_info"`
+	} `json:"response"`
+}
+
+// Get a creative from the creative service by Advertiser ID and Creative ID
+func (s *CreativeService) Get(advertiserID int, creativeID int) (*Creative, error) {
+	return s.GetContext(context.Background(), advertiserID, creativeID)
+}
+
+// GetContext is like Get but honours cancellation and deadlines on ctx
+func (s *CreativeService) GetContext(ctx context.Context, advertiserID int, creativeID int) (*Creative, error) {
+
+	path := fmt.Sprintf("creative?advertiser_id=%d&id=%d", advertiserID, creativeID)
+	req, err := s.client.newRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &creativeResponse{}
+	_, err = s.client.do(req, r)
+	if err != nil {
+		return nil, err
+	}
+
+	creative := &r.Obj.Creative
+	return creative, nil
+}
+
+// List the creatives of an advertiser
+func (s *CreativeService) List(advertiserID int, opt *ListOptions) ([]Creative, *Response, error) {
+	return s.ListContext(context.Background(), advertiserID, opt)
+}
+
+// ListContext is like List but honours cancellation and deadlines on ctx
+func (s *CreativeService) ListContext(ctx context.Context, advertiserID int, opt *ListOptions) ([]Creative, *Response, error) {
+	u, err := addOptions(fmt.Sprintf("creative?advertiser_id=%d", advertiserID), opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.newRequest(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	creatives := &creativeResponse{}
+	resp, err := s.client.do(req, creatives)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return creatives.Obj.Creatives, resp, err
+}
+
+// Iter returns an Iterator over every creative of the advertiser
+func (s *CreativeService) Iter(advertiserID int, opt *ListOptions) *Iterator[Creative] {
+	return s.IterContext(context.Background(), advertiserID, opt)
+}
+
+// IterContext is like Iter but stops once ctx is done
+func (s *CreativeService) IterContext(ctx context.Context, advertiserID int, opt *ListOptions) *Iterator[Creative] {
+	return newIterator(ctx, opt, func(ctx context.Context, opt *ListOptions) ([]Creative, *Response, error) {
+		return s.ListContext(ctx, advertiserID, opt)
+	})
+}
+
+// ListAll fetches every creative of the advertiser, walking through all
+// pages
+func (s *CreativeService) ListAll(advertiserID int, opt *ListOptions) ([]Creative, error) {
+	return s.ListAllContext(context.Background(), advertiserID, opt)
+}
+
+// ListAllContext is like ListAll but honours cancellation and deadlines on
+// ctx
+func (s *CreativeService) ListAllContext(ctx context.Context, advertiserID int, opt *ListOptions) ([]Creative, error) {
+	return listAll(ctx, opt, func(ctx context.Context, opt *ListOptions) ([]Creative, *Response, error) {
+		return s.ListContext(ctx, advertiserID, opt)
+	})
+}
+
+// Add a new creative to an advertiser
+func (s *CreativeService) Add(advertiserID int, item *Creative) (*Response, error) {
+	return s.AddContext(context.Background(), advertiserID, item)
+}
+
+// AddContext is like Add but honours cancellation and deadlines on ctx
+func (s *CreativeService) AddContext(ctx context.Context, advertiserID int, item *Creative) (*Response, error) {
+
+	data := struct {
+		Creative `json:"creative"`
+	}{*item}
+
+	req, err := s.client.newRequest(ctx, "POST", fmt.Sprintf("creative?advertiser_id=%d", advertiserID), data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Response{}
+	resp, err := s.client.do(req, result)
+	if err != nil {
+		return resp, err
+	}
+
+	item.ID = result.Obj.ID
+	return result, nil
+}
+
+// Update an existing creative with new data. Changing its content sends it
+// back for audit.
+func (s *CreativeService) Update(advertiserID int, item Creative) (*Response, error) {
+	return s.UpdateContext(context.Background(), advertiserID, item)
+}
+
+// UpdateContext is like Update but honours cancellation and deadlines on ctx
+func (s *CreativeService) UpdateContext(ctx context.Context, advertiserID int, item Creative) (*Response, error) {
+
+	data := struct {
+		Creative `json:"creative"`
+	}{item}
+
+	if item.ID < 1 {
+		return nil, errors.New("Update Creative requires a creative to have an ID already")
+	}
+
+	path := fmt.Sprintf("creative?advertiser_id=%d&id=%d", advertiserID, item.ID)
+	req, err := s.client.newRequest(ctx, "PUT", path, data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Response{}
+	resp, err := s.client.do(req, result)
+	if err != nil {
+		return resp, err
+	}
+
+	return result, nil
+}
+
+// Delete the specified creative
+func (s *CreativeService) Delete(advertiserID int, item Creative) error {
+	return s.DeleteContext(context.Background(), advertiserID, item)
+}
+
+// DeleteContext is like Delete but honours cancellation and deadlines on ctx
+func (s *CreativeService) DeleteContext(ctx context.Context, advertiserID int, item Creative) error {
+
+	if item.ID < 1 {
+		return errors.New("Delete Creative requires a creative to have an ID already")
+	}
+
+	path := fmt.Sprintf("creative?advertiser_id=%d&id=%d", advertiserID, item.ID)
+	req, err := s.client.newRequest(ctx, "DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.do(req, nil)
+	return err
+}
+
+// Upload sends an asset file, such as an image or video, to the creative
+// upload service. Set the returned MediaURL on a Creative to serve it.
+func (s *CreativeService) Upload(memberID int, fileName string, file io.Reader) (*CreativeMedia, error) {
+	return s.UploadContext(context.Background(), memberID, fileName, file)
+}
+
+// UploadContext is like Upload but honours cancellation and deadlines on ctx
+func (s *CreativeService) UploadContext(ctx context.Context, memberID int, fileName string, file io.Reader) (*CreativeMedia, error) {
+
+	if fileName == "" {
+		return nil, errors.New("Upload Creative requires a file name")
+	}
+
+	// The form is buffered so that the upload can be replayed:
+	body := &bytes.Buffer{}
+	form := multipart.NewWriter(body)
+
+	part, err := form.CreateFormFile("file", fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := io.Copy(part, file); err != nil {
+		return nil, err
+	}
+
+	if err := form.Close(); err != nil {
+		return nil, err
+	}
+
+	path := fmt.Sprintf("creative-upload?member_id=%d", memberID)
+	req, err := s.client.newRequest(ctx, "POST", path, bytes.NewReader(body.Bytes()))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", form.FormDataContentType())
+
+	r := &creativeResponse{}
+	_, err = s.client.do(req, r)
+	if err != nil {
+		return nil, err
+	}
+
+	media := &r.Obj.Media
+	if media.MediaURL == "" {
+		return nil, errors.New("Creative upload returned no media URL")
+	}
+
+	return media, nil
+}
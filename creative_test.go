@@ -0,0 +1,185 @@
+package appnexus
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestCreativeService_Get(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/creative", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("advertiser_id") != "2" || r.URL.Query().Get("id") != "31" {
+			t.Errorf("Creatives.Get requested %s, expected advertiser_id=2&id=31", r.URL.RawQuery)
+		}
+
+		fmt.Fprint(w, `{"response":
+            {"status":"OK",
+            "creative": {
+                "id": 31,
+                "name": "Pre-roll 15s",
+                "format": "url-vast",
+                "template": {"id": 6439},
+                "width": 1,
+                "height": 1,
+                "video_attribute": {"duration_ms": 15000, "wrapper": {"url": "https://ads.example.com/vast.xml"}},
+                "brand": {"id": 12, "name": "Acme"},
+                "categories": [{"id": 4}],
+                "audit_status": "audited",
+                "allow_audit": true
+            }}}`)
+	})
+
+	actual, err := client.Creatives.Get(2, 31)
+	if err != nil {
+		t.Fatalf("Creatives.Get returned error: %v", err)
+	}
+
+	if actual.Format != CreativeFormatVAST || actual.Template == nil || actual.Template.ID != 6439 || !actual.Audited() {
+		t.Errorf("Creatives.Get returned %+v", actual)
+	}
+
+	if v := actual.VideoAttribute; v == nil || v.DurationMS != 15000 || v.Wrapper == nil || v.Wrapper.URL != "https://ads.example.com/vast.xml" {
+		t.Errorf("Creatives.Get returned video attribute %+v", v)
+	}
+
+	if actual.Brand == nil || actual.Brand.ID != 12 || len(actual.Categories) != 1 {
+		t.Errorf("Creatives.Get returned brand %+v and categories %+v", actual.Brand, actual.Categories)
+	}
+}
+
+func TestCreativeService_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/creative", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("advertiser_id") != "2" {
+			t.Errorf("Creatives.List requested %s, expected advertiser_id=2", r.URL.RawQuery)
+		}
+
+		fmt.Fprint(w, `{"response": {"status":"OK", "count": 2,
+            "creatives": [{"id": 31, "audit_status": "pending"}, {"id": 32, "audit_status": "rejected", "audit_feedback": "Landing page broken"}]}}`)
+	})
+
+	actual, _, err := client.Creatives.List(2, nil)
+	if err != nil {
+		t.Errorf("Creatives.List returned error: %v", err)
+	}
+
+	if len(actual) != 2 || actual[0].Audited() || actual[1].AuditStatus != AuditStatusRejected || actual[1].AuditFeedback == "" {
+		t.Errorf("Creatives.List returned %+v", actual)
+	}
+}
+
+func TestCreativeService_Add(t *testing.T) {
+	setup()
+	defer teardown()
+
+	data := Creative{
+		Name:     "Third party 300x250",
+		Format:   CreativeFormatRawJS,
+		Template: &ObjectRef{ID: 5},
+		Width:    300,
+		Height:   250,
+		Content:  `document.write('<script src="https://ads.example.com/tag.js"></script>');`,
+	}
+
+	mux.HandleFunc("/creative", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Query().Get("advertiser_id") != "2" {
+			t.Errorf("Creatives.Add sent %s %s", r.Method, r.URL)
+		}
+
+		var body struct {
+			Creative Creative `json:"creative"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Creative.Content != data.Content || body.Creative.Template.ID != 5 {
+			t.Errorf("Creatives.Add sent %+v (%v)", body, err)
+		}
+
+		fmt.Fprint(w, `{"response": {"status":"OK", "id": 33 }}`)
+	})
+
+	actual, err := client.Creatives.Add(2, &data)
+	if err != nil {
+		t.Errorf("Creatives.Add returned error: %v", err)
+	}
+
+	if actual.Obj.ID != 33 || data.ID != 33 {
+		t.Errorf("Creatives.Add returned %+v and set ID %d, expected 33", actual.Obj, data.ID)
+	}
+}
+
+func TestCreativeService_Upload(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/creative-upload", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Query().Get("member_id") != "1" {
+			t.Errorf("Creatives.Upload sent %s %s", r.Method, r.URL)
+		}
+
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			t.Fatalf("Creatives.Upload sent no file: %v", err)
+		}
+		defer file.Close()
+
+		content, _ := ioutil.ReadAll(file)
+		if header.Filename != "banner.png" || string(content) != "PNG DATA" {
+			t.Errorf("Creatives.Upload sent %s with %q", header.Filename, content)
+		}
+
+		fmt.Fprint(w, `{"response": {"status":"OK",
+            "creative-upload": {"id": 8, "media_url": "https://cdn.adnxs.com/p/banner.png", "width": 300, "height": 250}}}`)
+	})
+
+	actual, err := client.Creatives.Upload(1, "banner.png", strings.NewReader("PNG DATA"))
+	if err != nil {
+		t.Fatalf("Creatives.Upload returned error: %v", err)
+	}
+
+	if actual.MediaURL != "https://cdn.adnxs.com/p/banner.png" || actual.Width != 300 {
+		t.Errorf("Creatives.Upload returned %+v", actual)
+	}
+}
+
+func TestCreativeService_Update(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/creative", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Query().Get("id") != "31" {
+			t.Errorf("Creatives.Update sent %s %s", r.Method, r.URL)
+		}
+
+		fmt.Fprint(w, `{"response": {"status":"OK" }}`)
+	})
+
+	if _, err := client.Creatives.Update(2, Creative{ID: 31, ClickURL: "https://example.com"}); err != nil {
+		t.Errorf("Creatives.Update returned error: %v", err)
+	}
+
+	if _, err := client.Creatives.Update(2, Creative{}); err == nil {
+		t.Error("Creatives.Update accepted a creative without an ID")
+	}
+}
+
+func TestCreativeService_Delete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/creative", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" || r.URL.Query().Get("id") != "31" {
+			t.Errorf("Creatives.Delete sent %s %s", r.Method, r.URL)
+		}
+	})
+
+	if err := client.Creatives.Delete(2, Creative{ID: 31}); err != nil {
+		t.Errorf("Creatives.Delete returned error: %v", err)
+	}
+}
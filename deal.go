@@ -0,0 +1,279 @@
+package appnexus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// DealService handles all requests to the deal service API, through which
+// sellers manage their deals
+type DealService struct {
+	*Response
+	client *Client
+}
+
+// Deal types, given by the ID of Deal.Type
+const (
+	DealTypeOpenAuction    = 1
+	DealTypePrivateAuction = 2
+)
+
+// Deal auction types, given by the ID of Deal.AuctionType
+const (
+	AuctionTypeStandard   = 1
+	AuctionTypeFixedPrice = 2
+)
+
+// DealBuyer is a buyer member, or one of its seats, given access to a deal
+type DealBuyer struct {
+	ID       int    `json:"id,omitempty"`
+	BidderID int    `json:"bidder_id,omitempty"`
+	Code     string `json:"code,omitempty"`
+	Name     string `json:"name,omitempty"`
+}
+
+// Deal is a private marketplace agreement between a seller and buyers. Its
+// FloorPrice applies to standard auctions and its AskPrice to fixed price
+// ones.
+type Deal struct {
+	ID           int         `json:"id,omitempty"`
+	Code         string      `json:"code,omitempty"`
+	Name         string      `json:"name,omitempty"`
+	Description  string      `json:"description,omitempty"`
+	Active       *bool       `json:"active,omitempty"`
+	Type         *ObjectRef  `json:"type,omitempty"`
+	AuctionType  *ObjectRef  `json:"auction_type,omitempty"`
+	Seller       *ObjectRef  `json:"seller,omitempty"`
+	Buyer        *DealBuyer  `json:"buyer,omitempty"`
+	Buyers       []DealBuyer `json:"buyers,omitempty"`
+	BuyerSeats   []DealBuyer `json:"buyer_seats,omitempty"`
+	FloorPrice   float64     `json:"floor_price,omitempty"`
+	UseDealFloor bool        `json:"use_deal_floor,omitempty"`
+	AskPrice     float64     `json:"ask_price,omitempty"`
+	Currency     string      `json:"currency,omitempty"`
+	StartDate    string      `json:"start_date,omitempty"`
+	EndDate      string      `json:"end_date,omitempty"`
+	ProfileID    int         `json:"profile_id,omitempty"`
+	Priority     int         `json:"priority,omitempty"`
+	Brands       []ObjectRef `json:"brands,omitempty"`
+	Categories   []ObjectRef `json:"categories,omitempty"`
+	LastModified string      `json:"last_modified,omitempty"`
+}
+
+// FixedPrice reports whether buyers pay the deal's AskPrice rather than
+// bidding in an auction
+func (d *Deal) FixedPrice() bool {
+	return d.AuctionType != nil && d.AuctionType.ID == AuctionTypeFixedPrice
+}
+
+type dealResponse struct {
+	*http.Response
+	Obj struct {
+		Deal    Deal   `json:"deal,omitempty"`
+		Deals   []Deal `json:"deals,omitempty"`
+		Error   string `json:"error"`
+		Status  string `json:"status"`
+		Service string `json:"service"`
+		Rate    Rate   `json:"dbg_info"`
+	} `json:"response"`
+}
+
+// Get a deal from the deal service by seller Member ID and Deal ID
+func (s *DealService) Get(memberID int, dealID int) (*Deal, error) {
+	return s.GetContext(context.Background(), memberID, dealID)
+}
+
+// GetContext is like Get but honours cancellation and deadlines on ctx
+func (s *DealService) GetContext(ctx context.Context, memberID int, dealID int) (*Deal, error) {
+
+	path := fmt.Sprintf("deal?member_id=%d&id=%d", memberID, dealID)
+	req, err := s.client.newRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &dealResponse{}
+	_, err = s.client.do(req, r)
+	if err != nil {
+		return nil, err
+	}
+
+	deal := &r.Obj.Deal
+	return deal, nil
+}
+
+// List the deals a seller member offers
+func (s *DealService) List(memberID int, opt *ListOptions) ([]Deal, *Response, error) {
+	return s.ListContext(context.Background(), memberID, opt)
+}
+
+// ListContext is like List but honours cancellation and deadlines on ctx
+func (s *DealService) ListContext(ctx context.Context, memberID int, opt *ListOptions) ([]Deal, *Response, error) {
+	return s.list(ctx, fmt.Sprintf("deal?member_id=%d", memberID), opt)
+}
+
+// ListByBuyer lists the deals a seller member offers to one buyer member
+func (s *DealService) ListByBuyer(memberID int, buyerMemberID int, opt *ListOptions) ([]Deal, *Response, error) {
+	return s.ListByBuyerContext(context.Background(), memberID, buyerMemberID, opt)
+}
+
+// ListByBuyerContext is like ListByBuyer but honours cancellation and
+// deadlines on ctx
+func (s *DealService) ListByBuyerContext(ctx context.Context, memberID int, buyerMemberID int, opt *ListOptions) ([]Deal, *Response, error) {
+	return s.list(ctx, fmt.Sprintf("deal?member_id=%d&buyer_id=%d", memberID, buyerMemberID), opt)
+}
+
+func (s *DealService) list(ctx context.Context, path string, opt *ListOptions) ([]Deal, *Response, error) {
+	u, err := addOptions(path, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.newRequest(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	deals := &dealResponse{}
+	resp, err := s.client.do(req, deals)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return deals.Obj.Deals, resp, err
+}
+
+// Iter returns an Iterator over every deal the seller member offers
+func (s *DealService) Iter(memberID int, opt *ListOptions) *Iterator[Deal] {
+	return s.IterContext(context.Background(), memberID, opt)
+}
+
+// IterContext is like Iter but stops once ctx is done
+func (s *DealService) IterContext(ctx context.Context, memberID int, opt *ListOptions) *Iterator[Deal] {
+	return newIterator(ctx, opt, func(ctx context.Context, opt *ListOptions) ([]Deal, *Response, error) {
+		return s.ListContext(ctx, memberID, opt)
+	})
+}
+
+// ListAll fetches every deal the seller member offers, walking through all
+// pages
+func (s *DealService) ListAll(memberID int, opt *ListOptions) ([]Deal, error) {
+	return s.ListAllContext(context.Background(), memberID, opt)
+}
+
+// ListAllContext is like ListAll but honours cancellation and deadlines on
+// ctx
+func (s *DealService) ListAllContext(ctx context.Context, memberID int, opt *ListOptions) ([]Deal, error) {
+	return listAll(ctx, opt, func(ctx context.Context, opt *ListOptions) ([]Deal, *Response, error) {
+		return s.ListContext(ctx, memberID, opt)
+	})
+}
+
+// IterByBuyer returns an Iterator over every deal the seller member offers
+// to one buyer member
+func (s *DealService) IterByBuyer(memberID int, buyerMemberID int, opt *ListOptions) *Iterator[Deal] {
+	return s.IterByBuyerContext(context.Background(), memberID, buyerMemberID, opt)
+}
+
+// IterByBuyerContext is like IterByBuyer but stops once ctx is done
+func (s *DealService) IterByBuyerContext(ctx context.Context, memberID int, buyerMemberID int, opt *ListOptions) *Iterator[Deal] {
+	return newIterator(ctx, opt, func(ctx context.Context, opt *ListOptions) ([]Deal, *Response, error) {
+		return s.ListByBuyerContext(ctx, memberID, buyerMemberID, opt)
+	})
+}
+
+// ListAllByBuyer fetches every deal the seller member offers to one buyer
+// member, walking through all pages
+func (s *DealService) ListAllByBuyer(memberID int, buyerMemberID int, opt *ListOptions) ([]Deal, error) {
+	return s.ListAllByBuyerContext(context.Background(), memberID, buyerMemberID, opt)
+}
+
+// ListAllByBuyerContext is like ListAllByBuyer but honours cancellation
+// and deadlines on ctx
+func (s *DealService) ListAllByBuyerContext(ctx context.Context, memberID int, buyerMemberID int, opt *ListOptions) ([]Deal, error) {
+	return listAll(ctx, opt, func(ctx context.Context, opt *ListOptions) ([]Deal, *Response, error) {
+		return s.ListByBuyerContext(ctx, memberID, buyerMemberID, opt)
+	})
+}
+
+// Add a new deal offered by a seller member
+func (s *DealService) Add(memberID int, item *Deal) (*Response, error) {
+	return s.AddContext(context.Background(), memberID, item)
+}
+
+// AddContext is like Add but honours cancellation and deadlines on ctx
+func (s *DealService) AddContext(ctx context.Context, memberID int, item *Deal) (*Response, error) {
+
+	data := struct {
+		Deal `json:"deal"`
+	}{*item}
+
+	req, err := s.client.newRequest(ctx, "POST", fmt.Sprintf("deal?member_id=%d", memberID), data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Response{}
+	resp, err := s.client.do(req, result)
+	if err != nil {
+		return resp, err
+	}
+
+	item.ID = result.Obj.ID
+	return result, nil
+}
+
+// Update an existing deal with new data
+func (s *DealService) Update(memberID int, item Deal) (*Response, error) {
+	return s.UpdateContext(context.Background(), memberID, item)
+}
+
+// UpdateContext is like Update but honours cancellation and deadlines on ctx
+func (s *DealService) UpdateContext(ctx context.Context, memberID int, item Deal) (*Response, error) {
+
+	data := struct {
+		Deal `json:"deal"`
+	}{item}
+
+	if item.ID < 1 {
+		return nil, errors.New("Update Deal requires a deal to have an ID already")
+	}
+
+	path := fmt.Sprintf("deal?member_id=%d&id=%d", memberID, item.ID)
+	req, err := s.client.newRequest(ctx, "PUT", path, data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Response{}
+	resp, err := s.client.do(req, result)
+	if err != nil {
+		return resp, err
+	}
+
+	return result, nil
+}
+
+// Delete the specified deal
+func (s *DealService) Delete(memberID int, item Deal) error {
+	return s.DeleteContext(context.Background(), memberID, item)
+}
+
+// DeleteContext is like Delete but honours cancellation and deadlines on ctx
+func (s *DealService) DeleteContext(ctx context.Context, memberID int, item Deal) error {
+
+	if item.ID < 1 {
+		return errors.New("Delete Deal requires a deal to have an ID already")
+	}
+
+	path := fmt.Sprintf("deal?member_id=%d&id=%d", memberID, item.ID)
+	req, err := s.client.newRequest(ctx, "DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.do(req, nil)
+	return err
+}
@@ -0,0 +1,133 @@
+package appnexus
+
+import (
+	"context"
+	"fmt"
+)
+
+// DealBuyerAccessService handles all requests to the deal buyer access
+// service API, through which buyers read the deals sellers have given them
+// access to
+type DealBuyerAccessService struct {
+	*Response
+	client *Client
+}
+
+// Get a deal available to a buyer by buyer Member ID and Deal ID
+func (s *DealBuyerAccessService) Get(memberID int, dealID int) (*Deal, error) {
+	return s.GetContext(context.Background(), memberID, dealID)
+}
+
+// GetContext is like Get but honours cancellation and deadlines on ctx
+func (s *DealBuyerAccessService) GetContext(ctx context.Context, memberID int, dealID int) (*Deal, error) {
+
+	path := fmt.Sprintf("deal-buyer-access?member_id=%d&id=%d", memberID, dealID)
+	req, err := s.client.newRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &dealResponse{}
+	_, err = s.client.do(req, r)
+	if err != nil {
+		return nil, err
+	}
+
+	deal := &r.Obj.Deal
+	return deal, nil
+}
+
+// List the deals a buyer member has access to
+func (s *DealBuyerAccessService) List(memberID int, opt *ListOptions) ([]Deal, *Response, error) {
+	return s.ListContext(context.Background(), memberID, opt)
+}
+
+// ListContext is like List but honours cancellation and deadlines on ctx
+func (s *DealBuyerAccessService) ListContext(ctx context.Context, memberID int, opt *ListOptions) ([]Deal, *Response, error) {
+	return s.list(ctx, fmt.Sprintf("deal-buyer-access?member_id=%d", memberID), opt)
+}
+
+// ListBySeller lists the deals a buyer member has access to from one seller
+// member
+func (s *DealBuyerAccessService) ListBySeller(memberID int, sellerMemberID int, opt *ListOptions) ([]Deal, *Response, error) {
+	return s.ListBySellerContext(context.Background(), memberID, sellerMemberID, opt)
+}
+
+// ListBySellerContext is like ListBySeller but honours cancellation and
+// deadlines on ctx
+func (s *DealBuyerAccessService) ListBySellerContext(ctx context.Context, memberID int, sellerMemberID int, opt *ListOptions) ([]Deal, *Response, error) {
+	return s.list(ctx, fmt.Sprintf("deal-buyer-access?member_id=%d&seller_id=%d", memberID, sellerMemberID), opt)
+}
+
+func (s *DealBuyerAccessService) list(ctx context.Context, path string, opt *ListOptions) ([]Deal, *Response, error) {
+	u, err := addOptions(path, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.newRequest(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	deals := &dealResponse{}
+	resp, err := s.client.do(req, deals)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return deals.Obj.Deals, resp, err
+}
+
+// Iter returns an Iterator over every deal the buyer member has access to
+func (s *DealBuyerAccessService) Iter(memberID int, opt *ListOptions) *Iterator[Deal] {
+	return s.IterContext(context.Background(), memberID, opt)
+}
+
+// IterContext is like Iter but stops once ctx is done
+func (s *DealBuyerAccessService) IterContext(ctx context.Context, memberID int, opt *ListOptions) *Iterator[Deal] {
+	return newIterator(ctx, opt, func(ctx context.Context, opt *ListOptions) ([]Deal, *Response, error) {
+		return s.ListContext(ctx, memberID, opt)
+	})
+}
+
+// ListAll fetches every deal the buyer member has access to, walking
+// through all pages
+func (s *DealBuyerAccessService) ListAll(memberID int, opt *ListOptions) ([]Deal, error) {
+	return s.ListAllContext(context.Background(), memberID, opt)
+}
+
+// ListAllContext is like ListAll but honours cancellation and deadlines on
+// ctx
+func (s *DealBuyerAccessService) ListAllContext(ctx context.Context, memberID int, opt *ListOptions) ([]Deal, error) {
+	return listAll(ctx, opt, func(ctx context.Context, opt *ListOptions) ([]Deal, *Response, error) {
+		return s.ListContext(ctx, memberID, opt)
+	})
+}
+
+// IterBySeller returns an Iterator over every deal the buyer member has
+// access to from one seller member
+func (s *DealBuyerAccessService) IterBySeller(memberID int, sellerMemberID int, opt *ListOptions) *Iterator[Deal] {
+	return s.IterBySellerContext(context.Background(), memberID, sellerMemberID, opt)
+}
+
+// IterBySellerContext is like IterBySeller but stops once ctx is done
+func (s *DealBuyerAccessService) IterBySellerContext(ctx context.Context, memberID int, sellerMemberID int, opt *ListOptions) *Iterator[Deal] {
+	return newIterator(ctx, opt, func(ctx context.Context, opt *ListOptions) ([]Deal, *Response, error) {
+		return s.ListBySellerContext(ctx, memberID, sellerMemberID, opt)
+	})
+}
+
+// ListAllBySeller fetches every deal the buyer member has access to from
+// one seller member, walking through all pages
+func (s *DealBuyerAccessService) ListAllBySeller(memberID int, sellerMemberID int, opt *ListOptions) ([]Deal, error) {
+	return s.ListAllBySellerContext(context.Background(), memberID, sellerMemberID, opt)
+}
+
+// ListAllBySellerContext is like ListAllBySeller but honours cancellation
+// and deadlines on ctx
+func (s *DealBuyerAccessService) ListAllBySellerContext(ctx context.Context, memberID int, sellerMemberID int, opt *ListOptions) ([]Deal, error) {
+	return listAll(ctx, opt, func(ctx context.Context, opt *ListOptions) ([]Deal, *Response, error) {
+		return s.ListBySellerContext(ctx, memberID, sellerMemberID, opt)
+	})
+}
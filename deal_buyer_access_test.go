@@ -0,0 +1,107 @@
+package appnexus
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestDealBuyerAccessService_Get(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/deal-buyer-access", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("member_id") != "7" || r.URL.Query().Get("id") != "131" {
+			t.Errorf("DealBuyerAccess.Get requested %s, expected member_id=7&id=131", r.URL.RawQuery)
+		}
+
+		fmt.Fprint(w, `{"response": {"status":"OK",
+            "deal": {"id": 131, "name": "Autos PMP", "seller": {"id": 1}, "floor_price": 4}}}`)
+	})
+
+	actual, err := client.DealBuyerAccess.Get(7, 131)
+	if err != nil {
+		t.Fatalf("DealBuyerAccess.Get returned error: %v", err)
+	}
+
+	if actual.ID != 131 || actual.Seller == nil || actual.Seller.ID != 1 || actual.FloorPrice != 4 {
+		t.Errorf("DealBuyerAccess.Get returned %+v", actual)
+	}
+}
+
+func TestDealBuyerAccessService_ListBySeller(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/deal-buyer-access", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("member_id") != "7" || r.URL.Query().Get("seller_id") != "1" {
+			t.Errorf("DealBuyerAccess.ListBySeller requested %s, expected member_id=7&seller_id=1", r.URL.RawQuery)
+		}
+
+		fmt.Fprint(w, `{"response": {"status":"OK", "count": 1, "deals": [{"id": 131, "seller": {"id": 1}}]}}`)
+	})
+
+	actual, _, err := client.DealBuyerAccess.ListBySeller(7, 1, nil)
+	if err != nil {
+		t.Errorf("DealBuyerAccess.ListBySeller returned error: %v", err)
+	}
+
+	if len(actual) != 1 || actual[0].Seller.ID != 1 {
+		t.Errorf("DealBuyerAccess.ListBySeller returned %+v", actual)
+	}
+}
+
+func TestDealBuyerAccessService_ListAll(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/deal-buyer-access", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("start_element") {
+		case "", "0":
+			fmt.Fprint(w, `{"response": {"status":"OK", "count": 2, "start_element": 0, "num_elements": 1, "deals": [{"id": 131}]}}`)
+		default:
+			fmt.Fprint(w, `{"response": {"status":"OK", "count": 2, "start_element": 1, "num_elements": 1, "deals": [{"id": 132}]}}`)
+		}
+	})
+
+	actual, err := client.DealBuyerAccess.ListAll(7, &ListOptions{NumElements: 1})
+	if err != nil {
+		t.Errorf("DealBuyerAccess.ListAll returned error: %v", err)
+	}
+
+	if len(actual) != 2 || actual[1].ID != 132 {
+		t.Errorf("DealBuyerAccess.ListAll returned %+v", actual)
+	}
+}
+
+func TestDealBuyerAccessService_IterBySeller(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/deal-buyer-access", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("seller_id") != "1" {
+			t.Errorf("DealBuyerAccess.IterBySeller requested %s, expected seller_id=1", r.URL.RawQuery)
+		}
+
+		switch r.URL.Query().Get("start_element") {
+		case "", "0":
+			fmt.Fprint(w, `{"response": {"status":"OK", "count": 2, "start_element": 0, "num_elements": 1, "deals": [{"id": 131}]}}`)
+		default:
+			fmt.Fprint(w, `{"response": {"status":"OK", "count": 2, "start_element": 1, "num_elements": 1, "deals": [{"id": 132}]}}`)
+		}
+	})
+
+	var ids []int
+	it := client.DealBuyerAccess.IterBySeller(7, 1, &ListOptions{NumElements: 1})
+	for it.Next() {
+		ids = append(ids, it.Value().ID)
+	}
+
+	if err := it.Err(); err != nil {
+		t.Errorf("DealBuyerAccess.IterBySeller returned error: %v", err)
+	}
+
+	if fmt.Sprint(ids) != "[131 132]" {
+		t.Errorf("DealBuyerAccess.IterBySeller visited %v, expected [131 132]", ids)
+	}
+}
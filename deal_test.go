@@ -0,0 +1,200 @@
+package appnexus
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestDealService_Get(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/deal", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("member_id") != "1" || r.URL.Query().Get("id") != "131" {
+			t.Errorf("Deals.Get requested %s, expected member_id=1&id=131", r.URL.RawQuery)
+		}
+
+		fmt.Fprint(w, `{"response":
+            {"status":"OK",
+            "deal": {
+                "id": 131,
+                "name": "Autos PMP",
+                "active": true,
+                "type": {"id": 2, "name": "Private Auction"},
+                "auction_type": {"id": 2, "name": "fixed_price"},
+                "ask_price": 6.5,
+                "seller": {"id": 1, "name": "Daily News Group"},
+                "buyer": {"id": 7, "bidder_id": 2, "name": "Acme DSP"},
+                "start_date": "2026-11-01 00:00:00",
+                "end_date": "2026-12-31 23:59:59",
+                "profile_id": 46
+            }}}`)
+	})
+
+	actual, err := client.Deals.Get(1, 131)
+	if err != nil {
+		t.Fatalf("Deals.Get returned error: %v", err)
+	}
+
+	if actual.Active == nil || !*actual.Active || actual.Type.ID != DealTypePrivateAuction || !actual.FixedPrice() || actual.AskPrice != 6.5 {
+		t.Errorf("Deals.Get returned %+v", actual)
+	}
+
+	if actual.Buyer == nil || actual.Buyer.ID != 7 || actual.Seller.ID != 1 || actual.ProfileID != 46 {
+		t.Errorf("Deals.Get returned buyer %+v and seller %+v", actual.Buyer, actual.Seller)
+	}
+}
+
+func TestDealService_ListByBuyer(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/deal", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("member_id") != "1" || r.URL.Query().Get("buyer_id") != "7" {
+			t.Errorf("Deals.ListByBuyer requested %s, expected member_id=1&buyer_id=7", r.URL.RawQuery)
+		}
+
+		fmt.Fprint(w, `{"response": {"status":"OK", "count": 1, "deals": [{"id": 131, "buyer": {"id": 7}}]}}`)
+	})
+
+	actual, _, err := client.Deals.ListByBuyer(1, 7, nil)
+	if err != nil {
+		t.Errorf("Deals.ListByBuyer returned error: %v", err)
+	}
+
+	if len(actual) != 1 || actual[0].Buyer.ID != 7 {
+		t.Errorf("Deals.ListByBuyer returned %+v", actual)
+	}
+}
+
+func TestDealService_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/deal", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("buyer_id") != "" {
+			t.Errorf("Deals.List requested %s, expected no buyer filter", r.URL.RawQuery)
+		}
+
+		fmt.Fprint(w, `{"response": {"status":"OK", "count": 2, "deals": [{"id": 131}, {"id": 132}]}}`)
+	})
+
+	actual, _, err := client.Deals.List(1, nil)
+	if err != nil {
+		t.Errorf("Deals.List returned error: %v", err)
+	}
+
+	if len(actual) != 2 || actual[1].ID != 132 || actual[1].FixedPrice() {
+		t.Errorf("Deals.List returned %+v", actual)
+	}
+}
+
+func TestDealService_Add(t *testing.T) {
+	setup()
+	defer teardown()
+
+	data := Deal{
+		Name:        "Sports PMP",
+		Type:        &ObjectRef{ID: DealTypePrivateAuction},
+		AuctionType: &ObjectRef{ID: AuctionTypeStandard},
+		FloorPrice:  3,
+		Buyer:       &DealBuyer{ID: 7},
+		BuyerSeats:  []DealBuyer{{BidderID: 2, Code: "seat-1"}},
+		ProfileID:   46,
+	}
+
+	mux.HandleFunc("/deal", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Query().Get("member_id") != "1" {
+			t.Errorf("Deals.Add sent %s %s", r.Method, r.URL)
+		}
+
+		var body struct {
+			Deal Deal `json:"deal"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Deal.FloorPrice != 3 || len(body.Deal.BuyerSeats) != 1 || body.Deal.Active != nil {
+			t.Errorf("Deals.Add sent %+v (%v)", body, err)
+		}
+
+		fmt.Fprint(w, `{"response": {"status":"OK", "id": 133 }}`)
+	})
+
+	actual, err := client.Deals.Add(1, &data)
+	if err != nil {
+		t.Errorf("Deals.Add returned error: %v", err)
+	}
+
+	if actual.Obj.ID != 133 || data.ID != 133 {
+		t.Errorf("Deals.Add returned %+v and set ID %d, expected 133", actual.Obj, data.ID)
+	}
+}
+
+func TestDealService_Update(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/deal", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Query().Get("id") != "131" {
+			t.Errorf("Deals.Update sent %s %s", r.Method, r.URL)
+		}
+
+		var body map[string]map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body["deal"]["active"] != false {
+			t.Errorf("Deals.Update sent %+v (%v), expected to deactivate the deal", body, err)
+		}
+
+		fmt.Fprint(w, `{"response": {"status":"OK" }}`)
+	})
+
+	inactive := false
+	if _, err := client.Deals.Update(1, Deal{ID: 131, Active: &inactive}); err != nil {
+		t.Errorf("Deals.Update returned error: %v", err)
+	}
+
+	if _, err := client.Deals.Update(1, Deal{}); err == nil {
+		t.Error("Deals.Update accepted a deal without an ID")
+	}
+}
+
+func TestDealService_Delete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/deal", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" || r.URL.Query().Get("id") != "131" {
+			t.Errorf("Deals.Delete sent %s %s", r.Method, r.URL)
+		}
+	})
+
+	if err := client.Deals.Delete(1, Deal{ID: 131}); err != nil {
+		t.Errorf("Deals.Delete returned error: %v", err)
+	}
+}
+
+func TestDealService_ListAllByBuyer(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/deal", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("buyer_id") != "7" {
+			t.Errorf("Deals.ListAllByBuyer requested %s, expected buyer_id=7", r.URL.RawQuery)
+		}
+
+		switch r.URL.Query().Get("start_element") {
+		case "", "0":
+			fmt.Fprint(w, `{"response": {"status":"OK", "count": 2, "start_element": 0, "num_elements": 1, "deals": [{"id": 131}]}}`)
+		default:
+			fmt.Fprint(w, `{"response": {"status":"OK", "count": 2, "start_element": 1, "num_elements": 1, "deals": [{"id": 132}]}}`)
+		}
+	})
+
+	actual, err := client.Deals.ListAllByBuyer(1, 7, &ListOptions{NumElements: 1})
+	if err != nil {
+		t.Errorf("Deals.ListAllByBuyer returned error: %v", err)
+	}
+
+	if len(actual) != 2 || actual[1].ID != 132 {
+		t.Errorf("Deals.ListAllByBuyer returned %+v", actual)
+	}
+}
@@ -0,0 +1,408 @@
+package appnexus
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// DomainListService handles all requests to the domain list service API,
+// which holds both domain lists and mobile app lists
+type DomainListService struct {
+	*Response
+	client *Client
+}
+
+// Domain list types: whether a list allows or blocks what it holds
+const (
+	DomainListTypeAllow = "white"
+	DomainListTypeBlock = "black"
+)
+
+// Domain list contents: web domains or mobile app bundle IDs
+const (
+	DomainListContentDomain = "domain"
+	DomainListContentApp    = "app"
+)
+
+// domainListChunkBytes bounds the domains sent in each request by Append,
+// keeping payloads well under the API's request size limit
+var domainListChunkBytes = 512 << 10
+
+// DomainList is a named list of domains, or of app bundle IDs, that
+// profiles allow or block
+type DomainList struct {
+	ID           int      `json:"id,omitempty"`
+	Name         string   `json:"name,omitempty"`
+	Description  string   `json:"description,omitempty"`
+	Type         string   `json:"type,omitempty"`
+	ListType     string   `json:"domain_list_type,omitempty"`
+	Domains      []string `json:"domains,omitempty"`
+	LastModified string   `json:"last_modified,omitempty"`
+}
+
+// DomainListBulkError is returned by Append when one of its requests fails.
+// The first Applied domains were already sent, so the call can be resumed
+// with the rest.
+type DomainListBulkError struct {
+	Applied int
+	Err     error
+}
+
+func (e *DomainListBulkError) Error() string {
+	return fmt.Sprintf("domain list: %d domains applied before: %v", e.Applied, e.Err)
+}
+
+// Unwrap returns the error of the failed request
+func (e *DomainListBulkError) Unwrap() error {
+	return e.Err
+}
+
+// domainListDomains is the body of a request setting the domains of a list.
+// Unlike DomainList it sends an empty list rather than leaving it out.
+type domainListDomains struct {
+	ID      int      `json:"id"`
+	Domains []string `json:"domains"`
+}
+
+type domainListResponse struct {
+	*http.Response
+	Obj struct {
+		DomainList  DomainList   `json:"domain-list,omitempty"`
+		DomainLists []DomainList `json:"domain-lists,omitempty"`
+		Error       string       `json:"error"`
+		Status      string       `json:"status"`
+		Service     string       `json:"service"`
+		Rate        Rate         `json:"dbg_info"`
+	} `json:"response"`
+}
+
+// Get a domain list from the domain list service by Member ID and Domain
+// List ID
+func (s *DomainListService) Get(memberID int, listID int) (*DomainList, error) {
+	return s.GetContext(context.Background(), memberID, listID)
+}
+
+// GetContext is like Get but honours cancellation and deadlines on ctx
+func (s *DomainListService) GetContext(ctx context.Context, memberID int, listID int) (*DomainList, error) {
+
+	path := fmt.Sprintf("domain-list?member_id=%d&id=%d", memberID, listID)
+	req, err := s.client.newRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &domainListResponse{}
+	_, err = s.client.do(req, r)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &r.Obj.DomainList
+	return list, nil
+}
+
+// List the domain lists of a member
+func (s *DomainListService) List(memberID int, opt *ListOptions) ([]DomainList, *Response, error) {
+	return s.ListContext(context.Background(), memberID, opt)
+}
+
+// ListContext is like List but honours cancellation and deadlines on ctx
+func (s *DomainListService) ListContext(ctx context.Context, memberID int, opt *ListOptions) ([]DomainList, *Response, error) {
+	u, err := addOptions(fmt.Sprintf("domain-list?member_id=%d", memberID), opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.newRequest(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	lists := &domainListResponse{}
+	resp, err := s.client.do(req, lists)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return lists.Obj.DomainLists, resp, err
+}
+
+// Iter returns an Iterator over every domain list of a member
+func (s *DomainListService) Iter(memberID int, opt *ListOptions) *Iterator[DomainList] {
+	return s.IterContext(context.Background(), memberID, opt)
+}
+
+// IterContext is like Iter but stops once ctx is done
+func (s *DomainListService) IterContext(ctx context.Context, memberID int, opt *ListOptions) *Iterator[DomainList] {
+	return newIterator(ctx, opt, func(ctx context.Context, opt *ListOptions) ([]DomainList, *Response, error) {
+		return s.ListContext(ctx, memberID, opt)
+	})
+}
+
+// ListAll fetches every domain list of a member, walking through all pages
+func (s *DomainListService) ListAll(memberID int, opt *ListOptions) ([]DomainList, error) {
+	return s.ListAllContext(context.Background(), memberID, opt)
+}
+
+// ListAllContext is like ListAll but honours cancellation and deadlines on
+// ctx
+func (s *DomainListService) ListAllContext(ctx context.Context, memberID int, opt *ListOptions) ([]DomainList, error) {
+	return listAll(ctx, opt, func(ctx context.Context, opt *ListOptions) ([]DomainList, *Response, error) {
+		return s.ListContext(ctx, memberID, opt)
+	})
+}
+
+// Add a new domain list to a member
+func (s *DomainListService) Add(memberID int, item *DomainList) (*Response, error) {
+	return s.AddContext(context.Background(), memberID, item)
+}
+
+// AddContext is like Add but honours cancellation and deadlines on ctx
+func (s *DomainListService) AddContext(ctx context.Context, memberID int, item *DomainList) (*Response, error) {
+
+	data := struct {
+		DomainList `json:"domain-list"`
+	}{*item}
+
+	req, err := s.client.newRequest(ctx, "POST", fmt.Sprintf("domain-list?member_id=%d", memberID), data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Response{}
+	resp, err := s.client.do(req, result)
+	if err != nil {
+		return resp, err
+	}
+
+	item.ID = result.Obj.ID
+	return result, nil
+}
+
+// Update an existing domain list with new data. Any Domains given replace
+// the whole list; use Append and Remove to change part of a large one.
+func (s *DomainListService) Update(memberID int, item DomainList) (*Response, error) {
+	return s.UpdateContext(context.Background(), memberID, item)
+}
+
+// UpdateContext is like Update but honours cancellation and deadlines on ctx
+func (s *DomainListService) UpdateContext(ctx context.Context, memberID int, item DomainList) (*Response, error) {
+
+	if item.ID < 1 {
+		return nil, errors.New("Update DomainList requires a domain list to have an ID already")
+	}
+
+	return s.put(ctx, fmt.Sprintf("domain-list?member_id=%d&id=%d", memberID, item.ID), item)
+}
+
+// Delete the specified domain list
+func (s *DomainListService) Delete(memberID int, item DomainList) error {
+	return s.DeleteContext(context.Background(), memberID, item)
+}
+
+// DeleteContext is like Delete but honours cancellation and deadlines on ctx
+func (s *DomainListService) DeleteContext(ctx context.Context, memberID int, item DomainList) error {
+
+	if item.ID < 1 {
+		return errors.New("Delete DomainList requires a domain list to have an ID already")
+	}
+
+	path := fmt.Sprintf("domain-list?member_id=%d&id=%d", memberID, item.ID)
+	req, err := s.client.newRequest(ctx, "DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.do(req, nil)
+	return err
+}
+
+// Append adds domains to a domain list without resending the domains it
+// already holds. Large sets are sent over several requests; the Response of
+// the last one is returned, and none is made when domains is empty. If a
+// request fails, the error is a *DomainListBulkError telling how many
+// domains were applied before it.
+func (s *DomainListService) Append(memberID int, listID int, domains []string) (*Response, error) {
+	return s.AppendContext(context.Background(), memberID, listID, domains)
+}
+
+// AppendContext is like Append but honours cancellation and deadlines on ctx
+func (s *DomainListService) AppendContext(ctx context.Context, memberID int, listID int, domains []string) (*Response, error) {
+	return s.bulk(ctx, fmt.Sprintf("domain-list?member_id=%d&id=%d&append=true", memberID, listID), listID, domains)
+}
+
+// Remove takes domains out of a domain list. AppNexus can only add to a list
+// in place, so Remove reads the list and sends back the domains left in a
+// single request that replaces them all. Changes made to the list in
+// between are lost, and no request is made when none of domains is on it.
+func (s *DomainListService) Remove(memberID int, listID int, domains []string) (*Response, error) {
+	return s.RemoveContext(context.Background(), memberID, listID, domains)
+}
+
+// RemoveContext is like Remove but honours cancellation and deadlines on ctx
+func (s *DomainListService) RemoveContext(ctx context.Context, memberID int, listID int, domains []string) (*Response, error) {
+
+	list, err := s.GetContext(ctx, memberID, listID)
+	if err != nil {
+		return nil, err
+	}
+
+	drop := make(map[string]bool, len(domains))
+	for _, d := range domains {
+		drop[normalizeDomain(d)] = true
+	}
+
+	kept := make([]string, 0, len(list.Domains))
+	for _, d := range list.Domains {
+		if !drop[normalizeDomain(d)] {
+			kept = append(kept, d)
+		}
+	}
+
+	if len(kept) == len(list.Domains) {
+		return nil, nil
+	}
+
+	path := fmt.Sprintf("domain-list?member_id=%d&id=%d", memberID, listID)
+	return s.put(ctx, path, domainListDomains{ID: listID, Domains: kept})
+}
+
+// Diff reads a local domain file, as read by ReadDomains, and returns the
+// domains to append to and remove from a domain list to make it match
+func (s *DomainListService) Diff(memberID int, listID int, file io.Reader) (adds []string, removes []string, err error) {
+	return s.DiffContext(context.Background(), memberID, listID, file)
+}
+
+// DiffContext is like Diff but honours cancellation and deadlines on ctx
+func (s *DomainListService) DiffContext(ctx context.Context, memberID int, listID int, file io.Reader) (adds []string, removes []string, err error) {
+
+	local, err := ReadDomains(file)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list, err := s.GetContext(ctx, memberID, listID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	adds, removes = DiffDomains(local, list.Domains)
+	return adds, removes, nil
+}
+
+func (s *DomainListService) bulk(ctx context.Context, path string, listID int, domains []string) (*Response, error) {
+	var result *Response
+	applied := 0
+	for _, chunk := range chunkDomains(domains, domainListChunkBytes) {
+		resp, err := s.put(ctx, path, domainListDomains{ID: listID, Domains: chunk})
+		if err != nil {
+			return resp, &DomainListBulkError{Applied: applied, Err: err}
+		}
+		result = resp
+		applied += len(chunk)
+	}
+
+	return result, nil
+}
+
+func (s *DomainListService) put(ctx context.Context, path string, item interface{}) (*Response, error) {
+
+	data := struct {
+		DomainList interface{} `json:"domain-list"`
+	}{item}
+
+	req, err := s.client.newRequest(ctx, "PUT", path, data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Response{}
+	resp, err := s.client.do(req, result)
+	if err != nil {
+		return resp, err
+	}
+
+	return result, nil
+}
+
+// chunkDomains splits domains into runs whose JSON encoding stays within
+// maxBytes, always putting at least one domain in each run
+func chunkDomains(domains []string, maxBytes int) [][]string {
+	var chunks [][]string
+	start, size := 0, 0
+	for i, d := range domains {
+		// a quoted string and its separating comma
+		n := len(d) + 3
+		if i > start && size+n > maxBytes {
+			chunks = append(chunks, domains[start:i])
+			start, size = i, 0
+		}
+		size += n
+	}
+
+	if start < len(domains) {
+		chunks = append(chunks, domains[start:])
+	}
+	return chunks
+}
+
+// ReadDomains reads a domain file holding one domain or app bundle ID per
+// line. Domains are trimmed and lowercased, and blank lines, duplicates and
+// lines starting with # are skipped.
+func ReadDomains(r io.Reader) ([]string, error) {
+	var domains []string
+	seen := make(map[string]bool)
+
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		d := normalizeDomain(sc.Text())
+		if d == "" || strings.HasPrefix(d, "#") || seen[d] {
+			continue
+		}
+		seen[d] = true
+		domains = append(domains, d)
+	}
+
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return domains, nil
+}
+
+// DiffDomains compares a local set of domains with a remote domain list and
+// returns those only held locally, to append, and those only held remotely,
+// to remove. Both keep the order they were given in.
+func DiffDomains(local []string, remote []string) (adds []string, removes []string) {
+	inLocal := make(map[string]bool, len(local))
+	for _, d := range local {
+		inLocal[normalizeDomain(d)] = true
+	}
+
+	inRemote := make(map[string]bool, len(remote))
+	for _, d := range remote {
+		d = normalizeDomain(d)
+		if d != "" && !inLocal[d] && !inRemote[d] {
+			removes = append(removes, d)
+		}
+		inRemote[d] = true
+	}
+
+	for _, d := range local {
+		d = normalizeDomain(d)
+		if d != "" && !inRemote[d] {
+			adds = append(adds, d)
+			inRemote[d] = true
+		}
+	}
+
+	return adds, removes
+}
+
+func normalizeDomain(d string) string {
+	return strings.ToLower(strings.TrimSpace(d))
+}
@@ -0,0 +1,278 @@
+package appnexus
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDomainListService_Get(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/domain-list", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("member_id") != "1" || r.URL.Query().Get("id") != "12" {
+			t.Errorf("DomainLists.Get requested %s, expected member_id=1&id=12", r.URL.RawQuery)
+		}
+
+		fmt.Fprint(w, `{"response": {"status":"OK",
+            "domain-list": {"id": 12, "name": "Blocked apps", "type": "black", "domain_list_type": "app",
+            "domains": ["com.example.game", "com.example.chat"]}}}`)
+	})
+
+	actual, err := client.DomainLists.Get(1, 12)
+	if err != nil {
+		t.Fatalf("DomainLists.Get returned error: %v", err)
+	}
+
+	if actual.Type != DomainListTypeBlock || actual.ListType != DomainListContentApp || len(actual.Domains) != 2 {
+		t.Errorf("DomainLists.Get returned %+v", actual)
+	}
+}
+
+func TestDomainListService_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/domain-list", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"response": {"status":"OK", "count": 2, "domain-lists": [{"id": 12}, {"id": 13}]}}`)
+	})
+
+	actual, _, err := client.DomainLists.List(1, nil)
+	if err != nil {
+		t.Errorf("DomainLists.List returned error: %v", err)
+	}
+
+	if len(actual) != 2 || actual[1].ID != 13 {
+		t.Errorf("DomainLists.List returned %+v", actual)
+	}
+}
+
+func TestDomainListService_Add(t *testing.T) {
+	setup()
+	defer teardown()
+
+	data := DomainList{Name: "Allowed news", Type: DomainListTypeAllow, Domains: []string{"example.com"}}
+
+	mux.HandleFunc("/domain-list", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Query().Get("member_id") != "1" {
+			t.Errorf("DomainLists.Add sent %s %s", r.Method, r.URL)
+		}
+
+		var body struct {
+			DomainList DomainList `json:"domain-list"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.DomainList.Type != "white" || len(body.DomainList.Domains) != 1 {
+			t.Errorf("DomainLists.Add sent %+v (%v)", body, err)
+		}
+
+		fmt.Fprint(w, `{"response": {"status":"OK", "id": 14 }}`)
+	})
+
+	actual, err := client.DomainLists.Add(1, &data)
+	if err != nil {
+		t.Errorf("DomainLists.Add returned error: %v", err)
+	}
+
+	if actual.Obj.ID != 14 || data.ID != 14 {
+		t.Errorf("DomainLists.Add returned %+v and set ID %d, expected 14", actual.Obj, data.ID)
+	}
+}
+
+func TestDomainListService_Update(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/domain-list", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Query().Get("id") != "12" || r.URL.Query().Get("append") != "" {
+			t.Errorf("DomainLists.Update sent %s %s", r.Method, r.URL)
+		}
+
+		fmt.Fprint(w, `{"response": {"status":"OK" }}`)
+	})
+
+	if _, err := client.DomainLists.Update(1, DomainList{ID: 12, Name: "Renamed"}); err != nil {
+		t.Errorf("DomainLists.Update returned error: %v", err)
+	}
+
+	if _, err := client.DomainLists.Update(1, DomainList{}); err == nil {
+		t.Error("DomainLists.Update accepted a list without an ID")
+	}
+}
+
+func TestDomainListService_Delete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/domain-list", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" || r.URL.Query().Get("id") != "12" {
+			t.Errorf("DomainLists.Delete sent %s %s", r.Method, r.URL)
+		}
+	})
+
+	if err := client.DomainLists.Delete(1, DomainList{ID: 12}); err != nil {
+		t.Errorf("DomainLists.Delete returned error: %v", err)
+	}
+}
+
+func TestDomainListService_Append(t *testing.T) {
+	setup()
+	defer teardown()
+
+	defer func(n int) { domainListChunkBytes = n }(domainListChunkBytes)
+	domainListChunkBytes = 100
+
+	var domains []string
+	for i := 0; i < 25; i++ {
+		domains = append(domains, fmt.Sprintf("site%02d.example", i))
+	}
+
+	var sent []string
+	requests := 0
+	mux.HandleFunc("/domain-list", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Query().Get("id") != "12" || r.URL.Query().Get("append") != "true" {
+			t.Errorf("DomainLists.Append sent %s %s", r.Method, r.URL)
+		}
+
+		var body struct {
+			DomainList DomainList `json:"domain-list"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.DomainList.ID != 12 {
+			t.Errorf("DomainLists.Append sent %+v (%v)", body, err)
+		}
+
+		requests++
+		sent = append(sent, body.DomainList.Domains...)
+		fmt.Fprint(w, `{"response": {"status":"OK" }}`)
+	})
+
+	if _, err := client.DomainLists.Append(1, 12, domains); err != nil {
+		t.Errorf("DomainLists.Append returned error: %v", err)
+	}
+
+	if requests < 2 || !reflect.DeepEqual(sent, domains) {
+		t.Errorf("DomainLists.Append sent %d requests with %v, expected several with %v", requests, sent, domains)
+	}
+
+	resp, err := client.DomainLists.Append(1, 12, nil)
+	if resp != nil || err != nil || requests < 2 {
+		t.Errorf("DomainLists.Append with no domains returned %v, %v", resp, err)
+	}
+}
+
+func TestDomainListService_AppendProgress(t *testing.T) {
+	setup()
+	defer teardown()
+
+	defer func(n int) { domainListChunkBytes = n }(domainListChunkBytes)
+	domainListChunkBytes = 24
+
+	requests := 0
+	mux.HandleFunc("/domain-list", func(w http.ResponseWriter, r *http.Request) {
+		if requests++; requests == 3 {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"response": {"error_id": "SYNTAX", "error": "invalid domain"}}`)
+			return
+		}
+		fmt.Fprint(w, `{"response": {"status":"OK" }}`)
+	})
+
+	// two domains fit in each request
+	domains := []string{"a.example", "b.example", "c.example", "d.example", "e.example", "f.example"}
+	_, err := client.DomainLists.Append(1, 12, domains)
+
+	var bulkErr *DomainListBulkError
+	if !errors.As(err, &bulkErr) || bulkErr.Applied != 4 || !errors.Is(err, ErrSyntax) {
+		t.Errorf("DomainLists.Append returned %v, expected 4 domains applied before a SYNTAX error", err)
+	}
+}
+
+func TestDomainListService_Remove(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var sent []string
+	puts := 0
+	mux.HandleFunc("/domain-list", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `{"response": {"status":"OK",
+                "domain-list": {"id": 12, "domains": ["a.example", "b.example", "c.example"]}}}`)
+		case "PUT":
+			if r.URL.Query().Get("id") != "12" || r.URL.Query().Get("append") != "" {
+				t.Errorf("DomainLists.Remove sent PUT %s", r.URL)
+			}
+
+			var body struct {
+				DomainList struct {
+					Domains *[]string `json:"domains"`
+				} `json:"domain-list"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.DomainList.Domains == nil {
+				t.Errorf("DomainLists.Remove sent %+v (%v), expected the domains to keep", body, err)
+			} else {
+				sent = *body.DomainList.Domains
+			}
+
+			puts++
+			fmt.Fprint(w, `{"response": {"status":"OK" }}`)
+		default:
+			t.Errorf("DomainLists.Remove sent %s %s", r.Method, r.URL)
+		}
+	})
+
+	if _, err := client.DomainLists.Remove(1, 12, []string{"B.example", "missing.example"}); err != nil {
+		t.Errorf("DomainLists.Remove returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(sent, []string{"a.example", "c.example"}) {
+		t.Errorf("DomainLists.Remove left %v, expected [a.example c.example]", sent)
+	}
+
+	if _, err := client.DomainLists.Remove(1, 12, []string{"a.example", "b.example", "c.example"}); err != nil || len(sent) != 0 {
+		t.Errorf("DomainLists.Remove of every domain left %v (%v), expected an empty list", sent, err)
+	}
+
+	if _, err := client.DomainLists.Remove(1, 12, []string{"missing.example"}); err != nil || puts != 2 {
+		t.Errorf("DomainLists.Remove of absent domains made %d updates (%v), expected none", puts-2, err)
+	}
+}
+
+func TestDomainListService_Diff(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/domain-list", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"response": {"status":"OK",
+            "domain-list": {"id": 12, "domains": ["kept.example", "stale.example"]}}}`)
+	})
+
+	file := strings.NewReader("# news sites\nKept.example\n\nnew.example\nnew.example\n")
+	adds, removes, err := client.DomainLists.Diff(1, 12, file)
+	if err != nil {
+		t.Fatalf("DomainLists.Diff returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(adds, []string{"new.example"}) || !reflect.DeepEqual(removes, []string{"stale.example"}) {
+		t.Errorf("DomainLists.Diff returned adds %v and removes %v", adds, removes)
+	}
+}
+
+func TestChunkDomains(t *testing.T) {
+	domains := []string{"a.com", "b.com", "c.com", "a-very-long-domain.example"}
+
+	// each short domain takes 8 bytes once quoted and separated
+	actual := chunkDomains(domains, 16)
+	expected := [][]string{{"a.com", "b.com"}, {"c.com"}, {"a-very-long-domain.example"}}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("chunkDomains returned %v, expected %v", actual, expected)
+	}
+
+	if chunkDomains(nil, 16) != nil {
+		t.Error("chunkDomains returned chunks for no domains")
+	}
+}
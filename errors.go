@@ -0,0 +1,94 @@
+package appnexus
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Sentinel errors for the classes of failure reported by the AppNexus API.
+// Use errors.Is to test an error returned by the client against them, and
+// errors.As with *Error to get at the full details.
+var (
+	ErrNoAuth      = errors.New("appnexus: not authenticated")
+	ErrUnauth      = errors.New("appnexus: not authorized")
+	ErrSyntax      = errors.New("appnexus: syntax error")
+	ErrNotFound    = errors.New("appnexus: not found")
+	ErrSystem      = errors.New("appnexus: system error")
+	ErrIntegrity   = errors.New("appnexus: integrity error")
+	ErrRateLimited = errors.New("appnexus: rate limit exceeded")
+)
+
+// ErrReauthFailed is returned when a request is still rejected with NOAUTH
+// after the client has logged in again
+var ErrReauthFailed = errors.New("appnexus: request not authenticated after logging in again")
+
+// Error is an error reported by the AppNexus API, either through the
+// error fields of the response object or through the HTTP status code
+type Error struct {
+	StatusCode  int
+	ID          string
+	Code        string
+	Message     string
+	Description string
+	Service     string
+	Method      string
+	RetryAfter  time.Duration
+	Response    *Response
+}
+
+// newError builds an Error from the HTTP response and, when available, the
+// decoded AppNexus response object
+func newError(r *http.Response, resp *Response) *Error {
+	e := &Error{
+		StatusCode: r.StatusCode,
+		Message:    r.Status,
+		RetryAfter: parseRetryAfter(r.Header),
+		Response:   resp,
+	}
+
+	if resp != nil {
+		e.ID = resp.Obj.ErrorID
+		e.Code = resp.Obj.ErrorCode
+		e.Description = resp.Obj.ErrorDescription
+		e.Service = resp.Obj.Service
+		e.Method = resp.Obj.Method
+		if resp.Obj.Error != "" {
+			e.Message = resp.Obj.Error
+		}
+	}
+
+	return e
+}
+
+func (e *Error) Error() string {
+	id := e.ID
+	if id == "" {
+		id = fmt.Sprintf("HTTP %d", e.StatusCode)
+	}
+
+	return fmt.Sprintf("AppNexus:checkResponse [%s]: %s", id, e.Message)
+}
+
+// Is reports whether the error belongs to the class of the target sentinel
+func (e *Error) Is(target error) bool {
+	switch target {
+	case ErrNoAuth:
+		return e.ID == "NOAUTH" || e.ID == "NOAUTH_DISABLED" || e.ID == "NOAUTH_EXPIRED"
+	case ErrUnauth:
+		return e.ID == "UNAUTH" || e.StatusCode == http.StatusForbidden
+	case ErrSyntax:
+		return e.ID == "SYNTAX"
+	case ErrNotFound:
+		return e.ID == "NOTFOUND" || e.StatusCode == http.StatusNotFound
+	case ErrSystem:
+		return e.ID == "SYSTEM"
+	case ErrIntegrity:
+		return e.ID == "INTEGRITY"
+	case ErrRateLimited:
+		return e.Code == "RATE_EXCEEDED" || e.StatusCode == http.StatusTooManyRequests
+	}
+
+	return false
+}
@@ -0,0 +1,255 @@
+package appnexus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// InsertionOrderService handles all requests to the insertion order service
+// API
+type InsertionOrderService struct {
+	*Response
+	client *Client
+}
+
+// Insertion order budget types
+const (
+	BudgetTypeRevenue    = "revenue"
+	BudgetTypeImpression = "impression"
+	BudgetTypeFlexible   = "flexible"
+)
+
+// BudgetInterval is a flight of a seamless insertion order or line item,
+// with its own dates and budget. Budgets left unset are unlimited.
+type BudgetInterval struct {
+	ID                 int      `json:"id,omitempty"`
+	ParentIntervalID   int      `json:"parent_interval_id,omitempty"`
+	Code               string   `json:"code,omitempty"`
+	StartDate          string   `json:"start_date,omitempty"`
+	EndDate            string   `json:"end_date,omitempty"`
+	Timezone           string   `json:"timezone,omitempty"`
+	LifetimeBudget     *float64 `json:"lifetime_budget,omitempty"`
+	LifetimeBudgetImps *int     `json:"lifetime_budget_imps,omitempty"`
+	DailyBudget        *float64 `json:"daily_budget,omitempty"`
+	DailyBudgetImps    *int     `json:"daily_budget_imps,omitempty"`
+	EnablePacing       bool     `json:"enable_pacing,omitempty"`
+	LifetimePacing     bool     `json:"lifetime_pacing,omitempty"`
+	LifetimePacingSpan int      `json:"lifetime_pacing_span,omitempty"`
+	UnderspendCatchUp  string   `json:"underspend_catchup_type,omitempty"`
+}
+
+// BillingPeriod is a period an insertion order is invoiced for, identified
+// by its billing (PO) code
+type BillingPeriod struct {
+	ID                 int      `json:"id,omitempty"`
+	BillingCode        string   `json:"billing_code,omitempty"`
+	StartDate          string   `json:"start_date,omitempty"`
+	EndDate            string   `json:"end_date,omitempty"`
+	LifetimeBudget     *float64 `json:"lifetime_budget,omitempty"`
+	LifetimeBudgetImps *int     `json:"lifetime_budget_imps,omitempty"`
+	State              string   `json:"state,omitempty"`
+}
+
+// InsertionOrder groups the line items of an advertiser under a common
+// budget and billing arrangement
+type InsertionOrder struct {
+	ID                 int              `json:"id,omitempty"`
+	Code               string           `json:"code,omitempty"`
+	Name               string           `json:"name,omitempty"`
+	State              string           `json:"state,omitempty"`
+	AdvertiserID       int              `json:"advertiser_id,omitempty"`
+	StartDate          string           `json:"start_date,omitempty"`
+	EndDate            string           `json:"end_date,omitempty"`
+	Timezone           string           `json:"timezone,omitempty"`
+	Currency           string           `json:"currency,omitempty"`
+	BudgetType         string           `json:"budget_type,omitempty"`
+	BudgetIntervals    []BudgetInterval `json:"budget_intervals,omitempty"`
+	BillingPeriods     []BillingPeriod  `json:"billing_periods,omitempty"`
+	BillingCode        string           `json:"billing_code,omitempty"`
+	LifetimeBudget     *float64         `json:"lifetime_budget,omitempty"`
+	LifetimeBudgetImps *int             `json:"lifetime_budget_imps,omitempty"`
+	DailyBudget        *float64         `json:"daily_budget,omitempty"`
+	DailyBudgetImps    *int             `json:"daily_budget_imps,omitempty"`
+	EnablePacing       bool             `json:"enable_pacing,omitempty"`
+	IsRunning          bool             `json:"is_running,omitempty"`
+	Comments           string           `json:"comments,omitempty"`
+	Labels             []Label          `json:"labels,omitempty"`
+	LastModified       string           `json:"last_modified,omitempty"`
+}
+
+// Seamless reports whether the insertion order budgets by its intervals
+// rather than by a single start and end date
+func (o *InsertionOrder) Seamless() bool {
+	return len(o.BudgetIntervals) > 0
+}
+
+type insertionOrderResponse struct {
+	*http.Response
+	Obj struct {
+		InsertionOrder  InsertionOrder   `json:"insertion-order,omitempty"`
+		InsertionOrders []InsertionOrder `json:"insertion-orders,omitempty"`
+		Error           string           `json:"error"`
+		Status          string           `json:"status"`
+		Service         string           `json:"service"`
+		Rate            Rate             `json:"dbg_info"`
+	} `json:"response"`
+}
+
+// Get an insertion order from the insertion order service by Advertiser ID
+// and Insertion Order ID
+func (s *InsertionOrderService) Get(advertiserID int, insertionOrderID int) (*InsertionOrder, error) {
+	return s.GetContext(context.Background(), advertiserID, insertionOrderID)
+}
+
+// GetContext is like Get but honours cancellation and deadlines on ctx
+func (s *InsertionOrderService) GetContext(ctx context.Context, advertiserID int, insertionOrderID int) (*InsertionOrder, error) {
+
+	path := fmt.Sprintf("insertion-order?advertiser_id=%d&id=%d", advertiserID, insertionOrderID)
+	req, err := s.client.newRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &insertionOrderResponse{}
+	_, err = s.client.do(req, r)
+	if err != nil {
+		return nil, err
+	}
+
+	insertionOrder := &r.Obj.InsertionOrder
+	return insertionOrder, nil
+}
+
+// List the insertion orders of an advertiser
+func (s *InsertionOrderService) List(advertiserID int, opt *ListOptions) ([]InsertionOrder, *Response, error) {
+	return s.ListContext(context.Background(), advertiserID, opt)
+}
+
+// ListContext is like List but honours cancellation and deadlines on ctx
+func (s *InsertionOrderService) ListContext(ctx context.Context, advertiserID int, opt *ListOptions) ([]InsertionOrder, *Response, error) {
+	u, err := addOptions(fmt.Sprintf("insertion-order?advertiser_id=%d", advertiserID), opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.newRequest(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	insertionOrders := &insertionOrderResponse{}
+	resp, err := s.client.do(req, insertionOrders)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return insertionOrders.Obj.InsertionOrders, resp, err
+}
+
+// Iter returns an Iterator over every insertion order of the advertiser
+func (s *InsertionOrderService) Iter(advertiserID int, opt *ListOptions) *Iterator[InsertionOrder] {
+	return s.IterContext(context.Background(), advertiserID, opt)
+}
+
+// IterContext is like Iter but stops once ctx is done
+func (s *InsertionOrderService) IterContext(ctx context.Context, advertiserID int, opt *ListOptions) *Iterator[InsertionOrder] {
+	return newIterator(ctx, opt, func(ctx context.Context, opt *ListOptions) ([]InsertionOrder, *Response, error) {
+		return s.ListContext(ctx, advertiserID, opt)
+	})
+}
+
+// ListAll fetches every insertion order of the advertiser, walking through
+// all pages
+func (s *InsertionOrderService) ListAll(advertiserID int, opt *ListOptions) ([]InsertionOrder, error) {
+	return s.ListAllContext(context.Background(), advertiserID, opt)
+}
+
+// ListAllContext is like ListAll but honours cancellation and deadlines on
+// ctx
+func (s *InsertionOrderService) ListAllContext(ctx context.Context, advertiserID int, opt *ListOptions) ([]InsertionOrder, error) {
+	return listAll(ctx, opt, func(ctx context.Context, opt *ListOptions) ([]InsertionOrder, *Response, error) {
+		return s.ListContext(ctx, advertiserID, opt)
+	})
+}
+
+// Add a new insertion order to an advertiser
+func (s *InsertionOrderService) Add(advertiserID int, item *InsertionOrder) (*Response, error) {
+	return s.AddContext(context.Background(), advertiserID, item)
+}
+
+// AddContext is like Add but honours cancellation and deadlines on ctx
+func (s *InsertionOrderService) AddContext(ctx context.Context, advertiserID int, item *InsertionOrder) (*Response, error) {
+
+	data := struct {
+		InsertionOrder `json:"insertion-order"`
+	}{*item}
+
+	req, err := s.client.newRequest(ctx, "POST", fmt.Sprintf("insertion-order?advertiser_id=%d", advertiserID), data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Response{}
+	resp, err := s.client.do(req, result)
+	if err != nil {
+		return resp, err
+	}
+
+	item.ID = result.Obj.ID
+	return result, nil
+}
+
+// Update an existing insertion order with new data
+func (s *InsertionOrderService) Update(advertiserID int, item InsertionOrder) (*Response, error) {
+	return s.UpdateContext(context.Background(), advertiserID, item)
+}
+
+// UpdateContext is like Update but honours cancellation and deadlines on ctx
+func (s *InsertionOrderService) UpdateContext(ctx context.Context, advertiserID int, item InsertionOrder) (*Response, error) {
+
+	data := struct {
+		InsertionOrder `json:"insertion-order"`
+	}{item}
+
+	if item.ID < 1 {
+		return nil, errors.New("Update InsertionOrder requires an insertion order to have an ID already")
+	}
+
+	path := fmt.Sprintf("insertion-order?advertiser_id=%d&id=%d", advertiserID, item.ID)
+	req, err := s.client.newRequest(ctx, "PUT", path, data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Response{}
+	resp, err := s.client.do(req, result)
+	if err != nil {
+		return resp, err
+	}
+
+	return result, nil
+}
+
+// Delete the specified insertion order
+func (s *InsertionOrderService) Delete(advertiserID int, item InsertionOrder) error {
+	return s.DeleteContext(context.Background(), advertiserID, item)
+}
+
+// DeleteContext is like Delete but honours cancellation and deadlines on ctx
+func (s *InsertionOrderService) DeleteContext(ctx context.Context, advertiserID int, item InsertionOrder) error {
+
+	if item.ID < 1 {
+		return errors.New("Delete InsertionOrder requires an insertion order to have an ID already")
+	}
+
+	path := fmt.Sprintf("insertion-order?advertiser_id=%d&id=%d", advertiserID, item.ID)
+	req, err := s.client.newRequest(ctx, "DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.do(req, nil)
+	return err
+}
@@ -0,0 +1,156 @@
+package appnexus
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestInsertionOrderService_Get(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/insertion-order", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("advertiser_id") != "2" || r.URL.Query().Get("id") != "9" {
+			t.Errorf("InsertionOrders.Get requested %s, expected advertiser_id=2&id=9", r.URL.RawQuery)
+		}
+
+		fmt.Fprint(w, `{"response":
+            {"status":"OK",
+            "insertion-order": {
+                "id": 9,
+                "name": "Q3 flight",
+                "state": "active",
+                "currency": "EUR",
+                "budget_type": "revenue",
+                "budget_intervals": [
+                    {"id": 1, "start_date": "2026-07-01 00:00:00", "end_date": "2026-07-31 23:59:59", "lifetime_budget": 5000},
+                    {"id": 2, "start_date": "2026-08-01 00:00:00", "end_date": null, "lifetime_budget": null}
+                ],
+                "billing_periods": [{"id": 3, "billing_code": "PO-1"}],
+                "labels": [{"id": 3, "name": "Trafficker", "value": "Sam"}]
+            }}}`)
+	})
+
+	actual, err := client.InsertionOrders.Get(2, 9)
+	if err != nil {
+		t.Fatalf("InsertionOrders.Get returned error: %v", err)
+	}
+
+	if actual.ID != 9 || actual.Currency != "EUR" || actual.BudgetType != BudgetTypeRevenue || !actual.Seamless() {
+		t.Errorf("InsertionOrders.Get returned %+v", actual)
+	}
+
+	intervals := actual.BudgetIntervals
+	if len(intervals) != 2 || intervals[0].LifetimeBudget == nil || *intervals[0].LifetimeBudget != 5000 || intervals[1].LifetimeBudget != nil {
+		t.Errorf("InsertionOrders.Get returned budget intervals %+v", intervals)
+	}
+
+	if len(actual.BillingPeriods) != 1 || actual.BillingPeriods[0].BillingCode != "PO-1" {
+		t.Errorf("InsertionOrders.Get returned billing periods %+v", actual.BillingPeriods)
+	}
+}
+
+func TestInsertionOrderService_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/insertion-order", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("advertiser_id") != "2" || r.URL.Query().Get("num_elements") != "1" {
+			t.Errorf("InsertionOrders.List requested %s", r.URL.RawQuery)
+		}
+
+		fmt.Fprint(w, `{"response":
+            {"status":"OK",
+            "count": 2,
+            "start_element": 0,
+            "num_elements": 1,
+            "insertion-orders": [{"id": 9, "name": "Q3 flight"}]}}`)
+	})
+
+	actual, _, err := client.InsertionOrders.List(2, &ListOptions{NumElements: 1})
+	if err != nil {
+		t.Errorf("InsertionOrders.List returned error: %v", err)
+	}
+
+	if len(actual) != 1 || actual[0].ID != 9 {
+		t.Errorf("InsertionOrders.List returned %+v", actual)
+	}
+}
+
+func TestInsertionOrderService_Add(t *testing.T) {
+	setup()
+	defer teardown()
+
+	budget := 1000.0
+	data := InsertionOrder{
+		Name:            "Q4 flight",
+		BudgetType:      BudgetTypeRevenue,
+		BudgetIntervals: []BudgetInterval{{StartDate: "2026-10-01 00:00:00", LifetimeBudget: &budget}},
+	}
+
+	mux.HandleFunc("/insertion-order", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Query().Get("advertiser_id") != "2" {
+			t.Errorf("InsertionOrders.Add sent %s %s", r.Method, r.URL)
+		}
+
+		var body map[string]map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body["insertion-order"]["name"] != "Q4 flight" {
+			t.Errorf("InsertionOrders.Add sent %+v (%v)", body, err)
+		}
+
+		fmt.Fprint(w, `{"response": {"status":"OK", "id": 10 }}`)
+	})
+
+	actual, err := client.InsertionOrders.Add(2, &data)
+	if err != nil {
+		t.Errorf("InsertionOrders.Add returned error: %v", err)
+	}
+
+	if actual.Obj.ID != 10 || data.ID != 10 {
+		t.Errorf("InsertionOrders.Add returned %+v and set ID %d, expected 10", actual.Obj, data.ID)
+	}
+}
+
+func TestInsertionOrderService_Update(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/insertion-order", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Query().Get("id") != "9" {
+			t.Errorf("InsertionOrders.Update sent %s %s", r.Method, r.URL)
+		}
+
+		fmt.Fprint(w, `{"response": {"status":"OK" }}`)
+	})
+
+	actual, err := client.InsertionOrders.Update(2, InsertionOrder{ID: 9, State: "inactive"})
+	if err != nil {
+		t.Errorf("InsertionOrders.Update returned error: %v", err)
+	}
+
+	if actual.Obj.Status != "OK" {
+		t.Errorf("InsertionOrders.Update returned %+v", actual)
+	}
+
+	if _, err := client.InsertionOrders.Update(2, InsertionOrder{}); err == nil {
+		t.Error("InsertionOrders.Update accepted an insertion order without an ID")
+	}
+}
+
+func TestInsertionOrderService_Delete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/insertion-order", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"response":{"error_id":"INTEGRITY","error":"insertion order has line items","service":"insertion-order","method":"delete"}}`)
+	})
+
+	err := client.InsertionOrders.Delete(2, InsertionOrder{ID: 9})
+	if !errors.Is(err, ErrIntegrity) {
+		t.Errorf("InsertionOrders.Delete returned %v, expected %v", err, ErrIntegrity)
+	}
+}
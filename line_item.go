@@ -0,0 +1,267 @@
+package appnexus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// LineItemService handles all requests to the line item service API
+type LineItemService struct {
+	*Response
+	client *Client
+}
+
+// Line item types: standard line items, and augmented line items (ALIs)
+// that optimise their own campaigns
+const (
+	LineItemTypeStandard  = "standard_v1"
+	LineItemTypeAugmented = "standard_v2"
+)
+
+// Line item revenue types
+const (
+	RevenueTypeCPM            = "cpm"
+	RevenueTypeCPC            = "cpc"
+	RevenueTypeCPA            = "cpa"
+	RevenueTypeVCPM           = "vcpm"
+	RevenueTypeFlatFee        = "flat_fee"
+	RevenueTypeCostPlusCPM    = "cost_plus_cpm"
+	RevenueTypeCostPlusMargin = "cost_plus_margin"
+)
+
+// Line item goal types
+const (
+	GoalTypeNone   = "none"
+	GoalTypeCPC    = "cpc"
+	GoalTypeCPA    = "cpa"
+	GoalTypeCTR    = "ctr"
+	GoalTypeCustom = "custom"
+)
+
+// ObjectRef refers to a related object, such as the campaigns of a line
+// item, by ID. The other fields are filled in by AppNexus when reading.
+type ObjectRef struct {
+	ID    int    `json:"id"`
+	Code  string `json:"code,omitempty"`
+	Name  string `json:"name,omitempty"`
+	State string `json:"state,omitempty"`
+}
+
+// LineItemValuation holds the performance goal targets of a line item
+type LineItemValuation struct {
+	GoalTarget    *float64 `json:"goal_target,omitempty"`
+	GoalThreshold *float64 `json:"goal_threshold,omitempty"`
+	MinMarginPct  *float64 `json:"min_margin_pct,omitempty"`
+	MaxAvgCPM     *float64 `json:"max_avg_cpm,omitempty"`
+	MinAvgCPM     *float64 `json:"min_avg_cpm,omitempty"`
+}
+
+// LineItem defines what an advertiser pays for, how it is paced and which
+// campaigns and insertion orders deliver it
+type LineItem struct {
+	ID                 int                `json:"id,omitempty"`
+	Code               string             `json:"code,omitempty"`
+	Name               string             `json:"name,omitempty"`
+	State              string             `json:"state,omitempty"`
+	AdvertiserID       int                `json:"advertiser_id,omitempty"`
+	LineItemType       string             `json:"line_item_type,omitempty"`
+	StartDate          string             `json:"start_date,omitempty"`
+	EndDate            string             `json:"end_date,omitempty"`
+	Timezone           string             `json:"timezone,omitempty"`
+	Currency           string             `json:"currency,omitempty"`
+	RevenueType        string             `json:"revenue_type,omitempty"`
+	RevenueValue       float64            `json:"revenue_value,omitempty"`
+	GoalType           string             `json:"goal_type,omitempty"`
+	GoalValue          *float64           `json:"goal_value,omitempty"`
+	Valuation          *LineItemValuation `json:"valuation,omitempty"`
+	BudgetIntervals    []BudgetInterval   `json:"budget_intervals,omitempty"`
+	LifetimeBudget     *float64           `json:"lifetime_budget,omitempty"`
+	LifetimeBudgetImps *int               `json:"lifetime_budget_imps,omitempty"`
+	DailyBudget        *float64           `json:"daily_budget,omitempty"`
+	DailyBudgetImps    *int               `json:"daily_budget_imps,omitempty"`
+	EnablePacing       bool               `json:"enable_pacing,omitempty"`
+	LifetimePacing     bool               `json:"lifetime_pacing,omitempty"`
+	ManageCreative     bool               `json:"manage_creative,omitempty"`
+	ProfileID          int                `json:"profile_id,omitempty"`
+	Campaigns          []ObjectRef        `json:"campaigns,omitempty"`
+	InsertionOrders    []ObjectRef        `json:"insertion_orders,omitempty"`
+	Labels             []Label            `json:"labels,omitempty"`
+	Comments           string             `json:"comments,omitempty"`
+	LastModified       string             `json:"last_modified,omitempty"`
+}
+
+// Augmented reports whether the line item is an augmented line item (ALI)
+func (l *LineItem) Augmented() bool {
+	return l.LineItemType == LineItemTypeAugmented
+}
+
+type lineItemResponse struct {
+	*http.Response
+	Obj struct {
+		LineItem  LineItem   `json:"line-item,omitempty"`
+		LineItems []LineItem `json:"line-items,omitempty"`
+		Error     string     `json:"error"`
+		Status    string     `json:"status"`
+		Service   string     `json:"service"`
+		Rate      Rate       `json:"dbg_info"`
+	} `json:"response"`
+}
+
+// Get a line item from the line item service by Advertiser ID and Line Item
+// ID
+func (s *LineItemService) Get(advertiserID int, lineItemID int) (*LineItem, error) {
+	return s.GetContext(context.Background(), advertiserID, lineItemID)
+}
+
+// GetContext is like Get but honours cancellation and deadlines on ctx
+func (s *LineItemService) GetContext(ctx context.Context, advertiserID int, lineItemID int) (*LineItem, error) {
+
+	path := fmt.Sprintf("line-item?advertiser_id=%d&id=%d", advertiserID, lineItemID)
+	req, err := s.client.newRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &lineItemResponse{}
+	_, err = s.client.do(req, r)
+	if err != nil {
+		return nil, err
+	}
+
+	lineItem := &r.Obj.LineItem
+	return lineItem, nil
+}
+
+// List the line items of an advertiser
+func (s *LineItemService) List(advertiserID int, opt *ListOptions) ([]LineItem, *Response, error) {
+	return s.ListContext(context.Background(), advertiserID, opt)
+}
+
+// ListContext is like List but honours cancellation and deadlines on ctx
+func (s *LineItemService) ListContext(ctx context.Context, advertiserID int, opt *ListOptions) ([]LineItem, *Response, error) {
+	u, err := addOptions(fmt.Sprintf("line-item?advertiser_id=%d", advertiserID), opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.newRequest(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	lineItems := &lineItemResponse{}
+	resp, err := s.client.do(req, lineItems)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return lineItems.Obj.LineItems, resp, err
+}
+
+// Iter returns an Iterator over every line item of the advertiser
+func (s *LineItemService) Iter(advertiserID int, opt *ListOptions) *Iterator[LineItem] {
+	return s.IterContext(context.Background(), advertiserID, opt)
+}
+
+// IterContext is like Iter but stops once ctx is done
+func (s *LineItemService) IterContext(ctx context.Context, advertiserID int, opt *ListOptions) *Iterator[LineItem] {
+	return newIterator(ctx, opt, func(ctx context.Context, opt *ListOptions) ([]LineItem, *Response, error) {
+		return s.ListContext(ctx, advertiserID, opt)
+	})
+}
+
+// ListAll fetches every line item of the advertiser, walking through all
+// pages
+func (s *LineItemService) ListAll(advertiserID int, opt *ListOptions) ([]LineItem, error) {
+	return s.ListAllContext(context.Background(), advertiserID, opt)
+}
+
+// ListAllContext is like ListAll but honours cancellation and deadlines on
+// ctx
+func (s *LineItemService) ListAllContext(ctx context.Context, advertiserID int, opt *ListOptions) ([]LineItem, error) {
+	return listAll(ctx, opt, func(ctx context.Context, opt *ListOptions) ([]LineItem, *Response, error) {
+		return s.ListContext(ctx, advertiserID, opt)
+	})
+}
+
+// Add a new line item to an advertiser
+func (s *LineItemService) Add(advertiserID int, item *LineItem) (*Response, error) {
+	return s.AddContext(context.Background(), advertiserID, item)
+}
+
+// AddContext is like Add but honours cancellation and deadlines on ctx
+func (s *LineItemService) AddContext(ctx context.Context, advertiserID int, item *LineItem) (*Response, error) {
+
+	data := struct {
+		LineItem `json:"line-item"`
+	}{*item}
+
+	req, err := s.client.newRequest(ctx, "POST", fmt.Sprintf("line-item?advertiser_id=%d", advertiserID), data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Response{}
+	resp, err := s.client.do(req, result)
+	if err != nil {
+		return resp, err
+	}
+
+	item.ID = result.Obj.ID
+	return result, nil
+}
+
+// Update an existing line item with new data
+func (s *LineItemService) Update(advertiserID int, item LineItem) (*Response, error) {
+	return s.UpdateContext(context.Background(), advertiserID, item)
+}
+
+// UpdateContext is like Update but honours cancellation and deadlines on ctx
+func (s *LineItemService) UpdateContext(ctx context.Context, advertiserID int, item LineItem) (*Response, error) {
+
+	data := struct {
+		LineItem `json:"line-item"`
+	}{item}
+
+	if item.ID < 1 {
+		return nil, errors.New("Update LineItem requires a line item to have an ID already")
+	}
+
+	path := fmt.Sprintf("line-item?advertiser_id=%d&id=%d", advertiserID, item.ID)
+	req, err := s.client.newRequest(ctx, "PUT", path, data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Response{}
+	resp, err := s.client.do(req, result)
+	if err != nil {
+		return resp, err
+	}
+
+	return result, nil
+}
+
+// Delete the specified line item
+func (s *LineItemService) Delete(advertiserID int, item LineItem) error {
+	return s.DeleteContext(context.Background(), advertiserID, item)
+}
+
+// DeleteContext is like Delete but honours cancellation and deadlines on ctx
+func (s *LineItemService) DeleteContext(ctx context.Context, advertiserID int, item LineItem) error {
+
+	if item.ID < 1 {
+		return errors.New("Delete LineItem requires a line item to have an ID already")
+	}
+
+	path := fmt.Sprintf("line-item?advertiser_id=%d&id=%d", advertiserID, item.ID)
+	req, err := s.client.newRequest(ctx, "DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.do(req, nil)
+	return err
+}
@@ -0,0 +1,150 @@
+package appnexus
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestLineItemService_Get(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/line-item", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("advertiser_id") != "2" || r.URL.Query().Get("id") != "11" {
+			t.Errorf("LineItems.Get requested %s, expected advertiser_id=2&id=11", r.URL.RawQuery)
+		}
+
+		fmt.Fprint(w, `{"response":
+            {"status":"OK",
+            "line-item": {
+                "id": 11,
+                "name": "Retargeting",
+                "line_item_type": "standard_v2",
+                "revenue_type": "cpm",
+                "revenue_value": 2.5,
+                "goal_type": "cpc",
+                "valuation": {"goal_target": 0.8},
+                "profile_id": 44,
+                "budget_intervals": [{"id": 1, "daily_budget": 100}],
+                "campaigns": [{"id": 21, "name": "Retargeting - Desktop", "state": "active"}],
+                "insertion_orders": [{"id": 9}]
+            }}}`)
+	})
+
+	actual, err := client.LineItems.Get(2, 11)
+	if err != nil {
+		t.Fatalf("LineItems.Get returned error: %v", err)
+	}
+
+	if !actual.Augmented() || actual.RevenueType != RevenueTypeCPM || actual.RevenueValue != 2.5 || actual.ProfileID != 44 {
+		t.Errorf("LineItems.Get returned %+v", actual)
+	}
+
+	if actual.Valuation == nil || actual.Valuation.GoalTarget == nil || *actual.Valuation.GoalTarget != 0.8 {
+		t.Errorf("LineItems.Get returned valuation %+v, expected a goal target of 0.8", actual.Valuation)
+	}
+
+	if len(actual.Campaigns) != 1 || actual.Campaigns[0].ID != 21 || len(actual.InsertionOrders) != 1 || actual.InsertionOrders[0].ID != 9 {
+		t.Errorf("LineItems.Get returned campaigns %+v and insertion orders %+v", actual.Campaigns, actual.InsertionOrders)
+	}
+}
+
+func TestLineItemService_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/line-item", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("advertiser_id") != "2" {
+			t.Errorf("LineItems.List requested %s, expected advertiser_id=2", r.URL.RawQuery)
+		}
+
+		fmt.Fprint(w, `{"response":
+            {"status":"OK",
+            "count": 2,
+            "line-items": [{"id": 11}, {"id": 12, "line_item_type": "standard_v1"}]}}`)
+	})
+
+	actual, _, err := client.LineItems.List(2, nil)
+	if err != nil {
+		t.Errorf("LineItems.List returned error: %v", err)
+	}
+
+	if len(actual) != 2 || actual[1].ID != 12 || actual[1].Augmented() {
+		t.Errorf("LineItems.List returned %+v", actual)
+	}
+}
+
+func TestLineItemService_Add(t *testing.T) {
+	setup()
+	defer teardown()
+
+	data := LineItem{
+		Name:            "Prospecting",
+		LineItemType:    LineItemTypeStandard,
+		RevenueType:     RevenueTypeCPM,
+		RevenueValue:    1.2,
+		InsertionOrders: []ObjectRef{{ID: 9}},
+	}
+
+	mux.HandleFunc("/line-item", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Query().Get("advertiser_id") != "2" {
+			t.Errorf("LineItems.Add sent %s %s", r.Method, r.URL)
+		}
+
+		var body struct {
+			LineItem LineItem `json:"line-item"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || len(body.LineItem.InsertionOrders) != 1 || body.LineItem.RevenueValue != 1.2 {
+			t.Errorf("LineItems.Add sent %+v (%v)", body, err)
+		}
+
+		fmt.Fprint(w, `{"response": {"status":"OK", "id": 13 }}`)
+	})
+
+	actual, err := client.LineItems.Add(2, &data)
+	if err != nil {
+		t.Errorf("LineItems.Add returned error: %v", err)
+	}
+
+	if actual.Obj.ID != 13 || data.ID != 13 {
+		t.Errorf("LineItems.Add returned %+v and set ID %d, expected 13", actual.Obj, data.ID)
+	}
+}
+
+func TestLineItemService_Update(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/line-item", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Query().Get("id") != "11" {
+			t.Errorf("LineItems.Update sent %s %s", r.Method, r.URL)
+		}
+
+		fmt.Fprint(w, `{"response": {"status":"OK" }}`)
+	})
+
+	if _, err := client.LineItems.Update(2, LineItem{ID: 11, State: "inactive"}); err != nil {
+		t.Errorf("LineItems.Update returned error: %v", err)
+	}
+
+	if _, err := client.LineItems.Update(2, LineItem{}); err == nil {
+		t.Error("LineItems.Update accepted a line item without an ID")
+	}
+}
+
+func TestLineItemService_Delete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/line-item", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" || r.URL.Query().Get("id") != "11" {
+			t.Errorf("LineItems.Delete sent %s %s", r.Method, r.URL)
+		}
+	})
+
+	if err := client.LineItems.Delete(2, LineItem{ID: 11}); err != nil {
+		t.Errorf("LineItems.Delete returned error: %v", err)
+	}
+}
@@ -0,0 +1,152 @@
+package appnexus
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log/slog"
+	"mime"
+	"net/http"
+	"time"
+)
+
+// redacted replaces secrets in logged requests
+const redacted = "[REDACTED]"
+
+// secretFields are the JSON fields whose values are never logged, such as
+// the password sent by Login
+var secretFields = map[string]bool{
+	"password": true,
+	"token":    true,
+}
+
+// secretHeaders are the HTTP headers whose values are never logged
+var secretHeaders = map[string]bool{
+	"Authorization": true,
+	"Cookie":        true,
+}
+
+// WithTrafficLogging logs every API exchange to l, see LogTraffic
+func WithTrafficLogging(l *slog.Logger) ClientOption {
+	return WithMiddleware(LogTraffic(l))
+}
+
+// LogTraffic returns Middleware logging every API exchange to l: the method,
+// path, headers and body of the request, and the status, latency, rate limit
+// counters and AppNexus error fields of the response. Passwords, tokens and
+// the Authorization header are redacted. Successful exchanges are logged at
+// debug level and failed ones at warn level.
+func LogTraffic(l *slog.Logger) Middleware {
+	return func(next Handler) Handler {
+		return func(req *http.Request) (*Response, error) {
+			ctx := req.Context()
+			attrs := []slog.Attr{
+				slog.String("method", req.Method),
+				slog.String("path", req.URL.RequestURI()),
+				slog.Any("headers", redactHeaders(req.Header)),
+			}
+
+			if body := requestBody(req); body != nil {
+				attrs = append(attrs, slog.Any("body", body))
+			}
+
+			start := time.Now()
+			resp, err := next(req)
+			attrs = append(attrs, slog.Duration("latency", time.Since(start)))
+
+			if resp != nil && resp.Response != nil {
+				attrs = append(attrs,
+					slog.Int("status", resp.StatusCode),
+					slog.String("service", resp.Obj.Service),
+					slog.Group("dbg_info",
+						slog.Int("reads", resp.Obj.Rate.Reads),
+						slog.Int("read_limit", resp.Obj.Rate.ReadLimit),
+						slog.Int("writes", resp.Obj.Rate.Writes),
+						slog.Int("write_limit", resp.Obj.Rate.WriteLimit),
+					),
+				)
+			}
+
+			level := slog.LevelDebug
+			if err != nil {
+				level = slog.LevelWarn
+				attrs = append(attrs, slog.String("error", err.Error()))
+				if resp != nil && resp.Obj.ErrorID != "" {
+					attrs = append(attrs,
+						slog.String("error_id", resp.Obj.ErrorID),
+						slog.String("error_code", resp.Obj.ErrorCode),
+						slog.String("error_description", resp.Obj.ErrorDescription),
+					)
+				}
+			}
+
+			l.LogAttrs(ctx, level, "appnexus: api call", attrs...)
+			return resp, err
+		}
+	}
+}
+
+// redactHeaders returns a copy of h with secret values replaced
+func redactHeaders(h http.Header) http.Header {
+	out := make(http.Header, len(h))
+	for k, v := range h {
+		if secretHeaders[http.CanonicalHeaderKey(k)] {
+			v = []string{redacted}
+		}
+		out[k] = v
+	}
+
+	return out
+}
+
+// requestBody returns the decoded JSON body of req with secret fields
+// replaced, without consuming the body that is about to be sent. Bodies that
+// are not sent as JSON, such as segment files and creative uploads, are
+// neither read nor logged.
+func requestBody(req *http.Request) interface{} {
+	if req.GetBody == nil {
+		return nil
+	}
+
+	if mt, _, err := mime.ParseMediaType(req.Header.Get("Content-Type")); err != nil || mt != "application/json" {
+		return nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil
+	}
+
+	defer body.Close()
+
+	data, err := ioutil.ReadAll(body)
+	if err != nil || len(data) == 0 {
+		return nil
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil
+	}
+
+	return redactJSON(v)
+}
+
+// redactJSON replaces the values of secret fields anywhere in v
+func redactJSON(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, field := range v {
+			if secretFields[k] {
+				v[k] = redacted
+				continue
+			}
+			v[k] = redactJSON(field)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactJSON(item)
+		}
+	}
+
+	return v
+}
@@ -0,0 +1,81 @@
+package appnexus
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestLogTraffic(t *testing.T) {
+	setup()
+	defer teardown()
+
+	buf := new(bytes.Buffer)
+	client.Use(LogTraffic(slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))))
+
+	mux.HandleFunc("/auth", func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "authn", Value: "secret-token"})
+		fmt.Fprint(w, `{"response":{"status":"OK","token":"secret-token"}}`)
+	})
+
+	mux.HandleFunc("/segment/1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"response":{"error_id":"NOTFOUND","error":"segment not found","service":"segment",
+			"dbg_info":{"reads":7,"read_limit":100}}}`)
+	})
+
+	if err := client.Login("user", "secret-password"); err != nil {
+		t.Fatalf("Login returned error: %v", err)
+	}
+
+	client.Segments.Get(1, 1)
+
+	out := buf.String()
+	for _, secret := range []string{"secret-password", "secret-token"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("logged %q, which contains the secret %q", out, secret)
+		}
+	}
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("logged %d lines, expected 2:\n%s", len(lines), out)
+	}
+
+	for _, expected := range []string{`"level":"DEBUG"`, `"path":"/auth"`, `"username":"user"`, `"password":"[REDACTED]"`, `"status":200`} {
+		if !strings.Contains(lines[0], expected) {
+			t.Errorf("logged %s, expected it to contain %s", lines[0], expected)
+		}
+	}
+
+	for _, expected := range []string{`"level":"WARN"`, `"Authorization":["[REDACTED]"]`, `"status":404`, `"error_id":"NOTFOUND"`, `"reads":7`} {
+		if !strings.Contains(lines[1], expected) {
+			t.Errorf("logged %s, expected it to contain %s", lines[1], expected)
+		}
+	}
+}
+
+func TestRequestBody_SkipsRawBodies(t *testing.T) {
+	for _, contentType := range []string{"application/octet-stream", "multipart/form-data; boundary=x", ""} {
+		req, _ := http.NewRequest("POST", "http://example.com/upload", nil)
+		req.Header.Set("Content-Type", contentType)
+		req.GetBody = func() (io.ReadCloser, error) {
+			t.Errorf("requestBody read a %q body", contentType)
+			return io.NopCloser(strings.NewReader(`{}`)), nil
+		}
+
+		if body := requestBody(req); body != nil {
+			t.Errorf("requestBody returned %v for a %q body", body, contentType)
+		}
+	}
+
+	req, _ := http.NewRequest("POST", "http://example.com/auth", strings.NewReader(`{"auth":{"password":"secret"}}`))
+	req.Header.Set("Content-Type", "application/json")
+	if body := fmt.Sprint(requestBody(req)); body != "map[auth:map[password:[REDACTED]]]" {
+		t.Errorf("requestBody returned %s for a JSON body", body)
+	}
+}
@@ -1,6 +1,7 @@
 package appnexus
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 )
@@ -90,13 +91,18 @@ type memberResponse struct {
 
 // Get a member from the Member Service API
 func (s *MemberService) Get(memberID int) (*Member, error) {
+	return s.GetContext(context.Background(), memberID)
+}
+
+// GetContext is like Get but honours cancellation and deadlines on ctx
+func (s *MemberService) GetContext(ctx context.Context, memberID int) (*Member, error) {
 
 	path := "member"
 	if memberID > 0 {
 		path = fmt.Sprintf("%s/%d", path, memberID)
 	}
 
-	req, err := s.client.newRequest("GET", path, nil)
+	req, err := s.client.newRequest(ctx, "GET", path, nil)
 
 	if err != nil {
 		return nil, err
@@ -114,11 +120,17 @@ func (s *MemberService) Get(memberID int) (*Member, error) {
 
 // GetDefault AppNexus member object and set the working member in AppNexus.Client
 func (s *MemberService) GetDefault() (*Member, error) {
-	member, err := s.Get(0)
+	return s.GetDefaultContext(context.Background())
+}
+
+// GetDefaultContext is like GetDefault but honours cancellation and deadlines
+// on ctx
+func (s *MemberService) GetDefaultContext(ctx context.Context) (*Member, error) {
+	member, err := s.GetContext(ctx, 0)
 	if err != nil {
 		return nil, err
 	}
 
-	s.client.MemberID = member.ID
+	s.client.setMemberID(member.ID)
 	return member, nil
 }
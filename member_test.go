@@ -3,6 +3,7 @@ package appnexus
 import (
 	"fmt"
 	"net/http"
+	"sync"
 	"testing"
 )
 
@@ -40,3 +41,38 @@ func TestMemberService_Get(t *testing.T) {
 		t.Errorf("Members.Get returned %+v, expected %+v", actual, expected)
 	}
 }
+
+func TestMemberService_GetDefaultConcurrent(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/member", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"response":{"status":"OK","member":{"id":7}}}`)
+	})
+
+	mux.HandleFunc("/segment/7", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"response":{"status":"OK","segment":{"id":1}}}`)
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if _, err := client.Members.GetDefault(); err != nil {
+				t.Errorf("Members.GetDefault returned error: %v", err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			if _, err := client.Segments.Get(7, 1); err != nil {
+				t.Errorf("Segments.Get returned error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if actual := client.CurrentMemberID(); actual != 7 {
+		t.Errorf("CurrentMemberID is %d, expected 7", actual)
+	}
+}
@@ -0,0 +1,74 @@
+package appnexus
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+)
+
+// Handler sends a single API request and returns the decoded AppNexus
+// response. On failure the response is returned alongside the error whenever
+// AppNexus answered, so that its status, error_id and dbg_info can be read.
+type Handler func(req *http.Request) (*Response, error)
+
+// Middleware wraps a Handler to act on every request before it is sent and
+// on every response once it has been decoded, for example to add tracing
+// headers, sign requests or audit responses. Each retry and
+// re-authentication attempt passes through the chain again.
+type Middleware func(next Handler) Handler
+
+// WithMiddleware adds middleware to the client. The first middleware given
+// is the outermost one, and sees requests first and responses last.
+func WithMiddleware(mw ...Middleware) ClientOption {
+	return func(c *Client) error {
+		c.Use(mw...)
+		return nil
+	}
+}
+
+// Use appends middleware to the chain every request is sent through
+func (c *Client) Use(mw ...Middleware) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.middleware = append(c.middleware[:len(c.middleware):len(c.middleware)], mw...)
+}
+
+// handler returns the exchange with the client's middleware wrapped around
+func (c *Client) handler() Handler {
+	c.mu.Lock()
+	mw := c.middleware
+	c.mu.Unlock()
+
+	h := Handler(c.exchange)
+	for i := len(mw) - 1; i >= 0; i-- {
+		h = mw[i](h)
+	}
+
+	return h
+}
+
+// Raw returns the undecoded response body
+func (r *Response) Raw() []byte {
+	return r.raw
+}
+
+// SetRaw replaces the response body, decoding it again into Obj. Middleware
+// can use it to rewrite a response before the client decodes it further.
+func (r *Response) SetRaw(data []byte) error {
+	var decoded Response
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			return err
+		}
+	}
+
+	r.Obj = decoded.Obj
+	r.raw = data
+	if r.Response != nil {
+		r.Body = ioutil.NopCloser(bytes.NewReader(data))
+	}
+
+	return nil
+}
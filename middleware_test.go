@@ -0,0 +1,100 @@
+package appnexus
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestMiddleware_Chain(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var order []string
+	tag := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(req *http.Request) (*Response, error) {
+				order = append(order, name+" request")
+				req.Header.Add("X-Trace", name)
+				resp, err := next(req)
+				order = append(order, name+" response "+resp.Obj.Service)
+				return resp, err
+			}
+		}
+	}
+
+	client.Use(tag("outer"), tag("inner"))
+
+	mux.HandleFunc("/segment/1", func(w http.ResponseWriter, r *http.Request) {
+		if actual, expected := fmt.Sprint(r.Header["X-Trace"]), "[outer inner]"; actual != expected {
+			t.Errorf("X-Trace headers are %v, expected %v", actual, expected)
+		}
+		fmt.Fprint(w, `{"response":{"status":"OK","service":"segment","segment":{"id":1}}}`)
+	})
+
+	if _, err := client.Segments.Get(1, 1); err != nil {
+		t.Fatalf("Segments.Get returned error: %v", err)
+	}
+
+	expected := "[outer request inner request inner response segment outer response segment]"
+	if actual := fmt.Sprint(order); actual != expected {
+		t.Errorf("middleware ran as %v, expected %v", actual, expected)
+	}
+}
+
+func TestMiddleware_SeesErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var seen *Response
+	client.Use(func(next Handler) Handler {
+		return func(req *http.Request) (*Response, error) {
+			resp, err := next(req)
+			seen = resp
+			return resp, err
+		}
+	})
+
+	mux.HandleFunc("/segment/1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"response":{"error_id":"NOTFOUND","error":"segment not found","dbg_info":{"reads":3}}}`)
+	})
+
+	_, err := client.Segments.Get(1, 1)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Segments.Get returned %v, expected %v", err, ErrNotFound)
+	}
+
+	if seen == nil || seen.StatusCode != http.StatusNotFound || seen.Obj.ErrorID != "NOTFOUND" || seen.Obj.Rate.Reads != 3 {
+		t.Errorf("middleware saw %+v, expected the decoded NOTFOUND response", seen)
+	}
+}
+
+func TestMiddleware_RewritesResponse(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client.Use(func(next Handler) Handler {
+		return func(req *http.Request) (*Response, error) {
+			resp, err := next(req)
+			if err != nil {
+				return resp, err
+			}
+			return resp, resp.SetRaw([]byte(`{"response":{"status":"OK","segment":{"id":2,"short_name":"rewritten"}}}`))
+		}
+	})
+
+	mux.HandleFunc("/segment/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"response":{"status":"OK","segment":{"id":1}}}`)
+	})
+
+	actual, err := client.Segments.Get(1, 1)
+	if err != nil {
+		t.Fatalf("Segments.Get returned error: %v", err)
+	}
+
+	if actual.ID != 2 || actual.ShortName != "rewritten" {
+		t.Errorf("Segments.Get returned %+v, expected the rewritten segment", actual)
+	}
+}
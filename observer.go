@@ -0,0 +1,119 @@
+package appnexus
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Observer receives the events needed to trace and measure API calls, such
+// as the OpenTelemetry adapter in the otelappnexus package. Its methods are
+// called from every goroutine using the client and must be safe for
+// concurrent use.
+type Observer interface {
+	// CallStarted is called before a call is first sent. The context it
+	// returns is used for the rest of the call, so that spans started by it
+	// become the parent of any HTTP level spans.
+	CallStarted(ctx context.Context, call *Call) context.Context
+
+	// CallFinished is called once a call has succeeded or finally failed
+	CallFinished(ctx context.Context, call *Call, err error)
+
+	// Retried is called before a failed attempt is retried after wait
+	Retried(ctx context.Context, call *Call, err error, wait time.Duration)
+
+	// RateLimitWaited is called when a request had to wait for the rate
+	// limiter before being sent
+	RateLimitWaited(ctx context.Context, method string, wait time.Duration)
+
+	// RateUpdated is called with the rate limit information of every response
+	RateUpdated(ctx context.Context, rate Rate)
+}
+
+// Call describes an API call as it goes through the client
+type Call struct {
+	Method   string
+	Path     string
+	Service  string
+	MemberID int
+	Start    time.Time
+
+	// Attempts counts the requests sent, including retries and replays
+	// after re-authentication
+	Attempts int
+
+	// Response is the last response received, which may be nil
+	Response *Response
+}
+
+// WithObserver reports API calls made by the client to o
+func WithObserver(o Observer) ClientOption {
+	return func(c *Client) error {
+		if o == nil {
+			return errors.New("WithObserver requires a non-nil Observer")
+		}
+
+		c.observer = o
+		return nil
+	}
+}
+
+// newCall describes the call about to be made with req
+func (c *Client) newCall(req *http.Request) *Call {
+	call := &Call{
+		Method:   req.Method,
+		Path:     req.URL.Path,
+		Service:  serviceName(req.URL.Path),
+		MemberID: c.CurrentMemberID(),
+		Start:    time.Now(),
+	}
+
+	if id, err := strconv.Atoi(req.URL.Query().Get("member_id")); err == nil {
+		call.MemberID = id
+	} else if id, ok := pathMemberID(req.URL.Path); ok {
+		call.MemberID = id
+	}
+
+	return call
+}
+
+// serviceName returns the AppNexus service addressed by a request path
+func serviceName(p string) string {
+	parts := strings.Split(strings.Trim(p, "/"), "/")
+	for i := len(parts) - 1; i >= 0; i-- {
+		if _, err := strconv.Atoi(parts[i]); err != nil {
+			return parts[i]
+		}
+	}
+
+	return ""
+}
+
+// pathMemberID returns the member ID from paths such as segment/{member_id}
+// and member/{member_id}
+func pathMemberID(p string) (int, bool) {
+	id, err := strconv.Atoi(path.Base(p))
+	if err != nil {
+		return 0, false
+	}
+
+	switch serviceName(p) {
+	case "segment", "member":
+		return id, true
+	}
+
+	return 0, false
+}
+
+// nopObserver is the Observer used when none has been set
+type nopObserver struct{}
+
+func (nopObserver) CallStarted(ctx context.Context, call *Call) context.Context { return ctx }
+func (nopObserver) CallFinished(context.Context, *Call, error)                  {}
+func (nopObserver) Retried(context.Context, *Call, error, time.Duration)        {}
+func (nopObserver) RateLimitWaited(context.Context, string, time.Duration)      {}
+func (nopObserver) RateUpdated(context.Context, Rate)                           {}
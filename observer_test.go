@@ -0,0 +1,105 @@
+package appnexus
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingObserver keeps the events it receives
+type recordingObserver struct {
+	mu       sync.Mutex
+	started  []Call
+	finished []error
+	retries  int
+	waits    []time.Duration
+	rates    []Rate
+}
+
+func (o *recordingObserver) CallStarted(ctx context.Context, call *Call) context.Context {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.started = append(o.started, *call)
+	return ctx
+}
+
+func (o *recordingObserver) CallFinished(ctx context.Context, call *Call, err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.finished = append(o.finished, err)
+}
+
+func (o *recordingObserver) Retried(ctx context.Context, call *Call, err error, wait time.Duration) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.retries++
+}
+
+func (o *recordingObserver) RateLimitWaited(ctx context.Context, method string, wait time.Duration) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.waits = append(o.waits, wait)
+}
+
+func (o *recordingObserver) RateUpdated(ctx context.Context, rate Rate) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.rates = append(o.rates, rate)
+}
+
+func TestObserver_Events(t *testing.T) {
+	setup()
+	defer teardown()
+
+	o := &recordingObserver{}
+	WithObserver(o)(client)
+	WithRetryPolicy(fastRetryPolicy)(client)
+
+	calls := 0
+	mux.HandleFunc("/segment/7", func(w http.ResponseWriter, r *http.Request) {
+		if calls++; calls == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		fmt.Fprint(w, `{"response":{"status":"OK","segment":{"id":1},
+			"dbg_info":{"reads":1,"read_limit":1,"read_limit_seconds":60}}}`)
+	})
+
+	if _, err := client.Segments.Get(7, 1); err != nil {
+		t.Fatalf("Segments.Get returned error: %v", err)
+	}
+
+	// The read budget is now spent, so the next call waits:
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	client.Segments.GetContext(ctx, 7, 1)
+
+	if len(o.started) != 2 || o.started[0].Service != "segment" || o.started[0].MemberID != 7 || o.started[0].Method != "GET" {
+		t.Errorf("started calls are %+v, expected two segment GETs for member 7", o.started)
+	}
+
+	if len(o.finished) != 2 || o.finished[0] != nil || o.finished[1] == nil {
+		t.Errorf("finished calls returned %v, expected success then failure", o.finished)
+	}
+
+	if o.retries != 1 || len(o.waits) != 1 || len(o.rates) != 1 || o.rates[0].ReadLimit != 1 {
+		t.Errorf("observed %d retries, waits %v and rates %+v, expected 1 of each", o.retries, o.waits, o.rates)
+	}
+}
+
+func TestServiceName(t *testing.T) {
+	for p, expected := range map[string]string{
+		"/segment/12":        "segment",
+		"/member":            "member",
+		"/api/advertiser":    "advertiser",
+		"/batch-segment/3/4": "batch-segment",
+		"/":                  "",
+	} {
+		if actual := serviceName(p); actual != expected {
+			t.Errorf("serviceName(%q) is %q, expected %q", p, actual, expected)
+		}
+	}
+}
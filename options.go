@@ -0,0 +1,91 @@
+package appnexus
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ClientOption configures a Client created by NewClient
+type ClientOption func(*Client) error
+
+// WithHTTPClient makes the client send requests through hc, which allows a
+// custom transport, proxy or TLS configuration
+func WithHTTPClient(hc *http.Client) ClientOption {
+	return func(c *Client) error {
+		if hc == nil {
+			return errors.New("WithHTTPClient requires a non-nil *http.Client")
+		}
+
+		c.client = hc
+		return nil
+	}
+}
+
+// WithTimeout limits the time taken by each HTTP request, including reading
+// the response body. It applies to the HTTP client in use once all options
+// have run, whatever their order, and that client is copied rather than
+// modified.
+func WithTimeout(d time.Duration) ClientOption {
+	return func(c *Client) error {
+		if d <= 0 {
+			return errors.New("WithTimeout requires a positive duration")
+		}
+
+		c.timeout = d
+		return nil
+	}
+}
+
+// WithUserAgentSuffix appends s to the User-Agent header sent with every
+// request, to identify the application using the client
+func WithUserAgentSuffix(s string) ClientOption {
+	return func(c *Client) error {
+		if s = strings.TrimSpace(s); s != "" {
+			c.UserAgent += " " + s
+		}
+
+		return nil
+	}
+}
+
+// WithLogger sets the logger the client reports retries and
+// re-authentication to. Nothing is logged by default.
+func WithLogger(l *slog.Logger) ClientOption {
+	return func(c *Client) error {
+		if l == nil {
+			return errors.New("WithLogger requires a non-nil *slog.Logger")
+		}
+
+		c.logger = l
+		return nil
+	}
+}
+
+// WithRetryPolicy replaces DefaultRetryPolicy for the client
+func WithRetryPolicy(p RetryPolicy) ClientOption {
+	return func(c *Client) error {
+		c.RetryPolicy = p
+		return nil
+	}
+}
+
+// WithRateLimit turns the client side rate limiter on or off. It is on by
+// default; turn it off when requests are already throttled elsewhere.
+func WithRateLimit(enabled bool) ClientOption {
+	return func(c *Client) error {
+		c.limiter.disabled = !enabled
+		return nil
+	}
+}
+
+// discardHandler is a slog.Handler that drops every record
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
@@ -0,0 +1,93 @@
+package appnexus
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewClient_Options(t *testing.T) {
+	hc := &http.Client{}
+	p := RetryPolicy{MaxAttempts: 7}
+
+	c, err := NewClient("http://sand.api.appnexus.com/",
+		WithHTTPClient(hc),
+		WithTimeout(5*time.Second),
+		WithUserAgentSuffix("my-app/1.0"),
+		WithRetryPolicy(p),
+		WithRateLimit(false),
+	)
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+
+	if c.client.Timeout != 5*time.Second || hc.Timeout != 0 {
+		t.Errorf("NewClient timeout is %v (given client %v), expected 5s on a copy", c.client.Timeout, hc.Timeout)
+	}
+
+	if actual, expected := c.UserAgent, "github.com/adwww/appnexus go-appnexus-client my-app/1.0"; actual != expected {
+		t.Errorf("NewClient agent is %v, expected %v", actual, expected)
+	}
+
+	if c.RetryPolicy != p {
+		t.Errorf("NewClient retry policy is %+v, expected %+v", c.RetryPolicy, p)
+	}
+
+	c.setRate(Rate{Time: time.Now(), ReadLimit: 1, ReadLimitSeconds: 60, Reads: 1})
+	if actual := c.limiter.reserve("GET", time.Now()); actual != 0 {
+		t.Errorf("disabled rate limiter waited %v, expected 0", actual)
+	}
+}
+
+func TestNewClient_TimeoutBeforeHTTPClient(t *testing.T) {
+	hc := &http.Client{}
+
+	c, err := NewClient("http://sand.api.appnexus.com/", WithTimeout(5*time.Second), WithHTTPClient(hc))
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+
+	if c.client.Timeout != 5*time.Second || hc.Timeout != 0 {
+		t.Errorf("NewClient timeout is %v (given client %v), expected 5s on a copy", c.client.Timeout, hc.Timeout)
+	}
+}
+
+func TestNewClient_InvalidOption(t *testing.T) {
+	if _, err := NewClient("http://sand.api.appnexus.com/", WithHTTPClient(nil)); err == nil {
+		t.Errorf("NewClient expected an error for a nil HTTP client")
+	}
+
+	if _, err := NewClient("http://sand.api.appnexus.com/", WithTimeout(0)); err == nil {
+		t.Errorf("NewClient expected an error for a zero timeout")
+	}
+}
+
+func TestWithLogger(t *testing.T) {
+	setup()
+	defer teardown()
+
+	buf := new(bytes.Buffer)
+	WithLogger(slog.New(slog.NewTextHandler(buf, nil)))(client)
+	WithRetryPolicy(fastRetryPolicy)(client)
+
+	calls := 0
+	mux.HandleFunc("/segment/1", func(w http.ResponseWriter, r *http.Request) {
+		if calls++; calls == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		fmt.Fprint(w, `{"response":{"status":"OK","segment":{"id":1}}}`)
+	})
+
+	if _, err := client.Segments.Get(1, 1); err != nil {
+		t.Fatalf("Segments.Get returned error: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "appnexus: retrying request") {
+		t.Errorf("logged %q, expected a retry message", buf.String())
+	}
+}
@@ -0,0 +1,232 @@
+// Package otelappnexus reports the API calls made by an appnexus.Client as
+// OpenTelemetry spans and metrics. Metrics can be exposed to Prometheus
+// through the OpenTelemetry Prometheus exporter.
+//
+//	o, err := otelappnexus.NewObserver()
+//	...
+//	c, err := appnexus.NewClient(endpoint, appnexus.WithObserver(o))
+package otelappnexus
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/adwww/appnexus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName identifies this package to OpenTelemetry
+const instrumentationName = "github.com/adwww/appnexus/otelappnexus"
+
+// Option configures an Observer
+type Option func(*config)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+}
+
+// WithTracerProvider sets the provider spans are created with, instead of
+// the global one
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = tp
+	}
+}
+
+// WithMeterProvider sets the provider metrics are recorded with, instead of
+// the global one
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = mp
+	}
+}
+
+// Observer is an appnexus.Observer creating a span for every API call and
+// recording these metrics:
+//
+//	appnexus.client.requests               calls made, by service, method and error_id
+//	appnexus.client.duration               call latency in seconds, including retries
+//	appnexus.client.retries                attempts retried after a transient failure
+//	appnexus.client.rate_limit.wait        time spent waiting on the rate limiter
+//	appnexus.client.rate_limit.remaining   read and write budget left in the window
+type Observer struct {
+	tracer    trace.Tracer
+	requests  metric.Int64Counter
+	duration  metric.Float64Histogram
+	retries   metric.Int64Counter
+	rateWait  metric.Float64Histogram
+	remaining metric.Int64ObservableGauge
+
+	mu   sync.Mutex
+	rate *appnexus.Rate
+}
+
+// NewObserver returns an Observer using the global OpenTelemetry providers
+// unless others are given
+func NewObserver(opts ...Option) (*Observer, error) {
+	cfg := config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+	}
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	o := &Observer{tracer: cfg.tracerProvider.Tracer(instrumentationName)}
+	meter := cfg.meterProvider.Meter(instrumentationName)
+
+	var err, e error
+	o.requests, e = meter.Int64Counter("appnexus.client.requests",
+		metric.WithDescription("AppNexus API calls made"))
+	err = errors.Join(err, e)
+
+	o.duration, e = meter.Float64Histogram("appnexus.client.duration", metric.WithUnit("s"),
+		metric.WithDescription("AppNexus API call latency, including retries"))
+	err = errors.Join(err, e)
+
+	o.retries, e = meter.Int64Counter("appnexus.client.retries",
+		metric.WithDescription("AppNexus API requests retried after a transient failure"))
+	err = errors.Join(err, e)
+
+	o.rateWait, e = meter.Float64Histogram("appnexus.client.rate_limit.wait", metric.WithUnit("s"),
+		metric.WithDescription("Time spent waiting on the AppNexus rate limit"))
+	err = errors.Join(err, e)
+
+	o.remaining, e = meter.Int64ObservableGauge("appnexus.client.rate_limit.remaining",
+		metric.WithDescription("AppNexus read and write budget left in the current window"),
+		metric.WithInt64Callback(o.observeRemaining))
+	err = errors.Join(err, e)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return o, nil
+}
+
+// CallStarted starts the span for an API call
+func (o *Observer) CallStarted(ctx context.Context, call *appnexus.Call) context.Context {
+	attrs := []attribute.KeyValue{
+		attribute.String("http.request.method", call.Method),
+		attribute.String("url.path", call.Path),
+		attribute.String("appnexus.service", call.Service),
+	}
+
+	if call.MemberID > 0 {
+		attrs = append(attrs, attribute.Int("appnexus.member_id", call.MemberID))
+	}
+
+	ctx, _ = o.tracer.Start(ctx, "AppNexus "+call.Method+" "+call.Service,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+		trace.WithTimestamp(call.Start),
+	)
+
+	return ctx
+}
+
+// CallFinished ends the span for an API call and records its metrics
+func (o *Observer) CallFinished(ctx context.Context, call *appnexus.Call, err error) {
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.Int("appnexus.attempts", call.Attempts))
+
+	if call.Response != nil && call.Response.Response != nil {
+		span.SetAttributes(attribute.Int("http.response.status_code", call.Response.StatusCode))
+	}
+
+	errorID := ""
+	var apiErr *appnexus.Error
+	if errors.As(err, &apiErr) {
+		errorID = apiErr.ID
+		span.SetAttributes(attribute.String("appnexus.error_id", apiErr.ID))
+	}
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+
+	attrs := metric.WithAttributes(
+		attribute.String("appnexus.service", call.Service),
+		attribute.String("http.request.method", call.Method),
+		attribute.String("appnexus.error_id", errorID),
+		attribute.Bool("error", err != nil),
+	)
+
+	o.requests.Add(ctx, 1, attrs)
+	o.duration.Record(ctx, time.Since(call.Start).Seconds(), attrs)
+}
+
+// Retried records a retry on the call's span and in the retry counter
+func (o *Observer) Retried(ctx context.Context, call *appnexus.Call, err error, wait time.Duration) {
+	trace.SpanFromContext(ctx).AddEvent("retry", trace.WithAttributes(
+		attribute.Int("appnexus.attempt", call.Attempts),
+		attribute.Float64("appnexus.retry_wait", wait.Seconds()),
+		attribute.String("error", err.Error()),
+	))
+
+	o.retries.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("appnexus.service", call.Service),
+		attribute.String("http.request.method", call.Method),
+	))
+}
+
+// RateLimitWaited records time spent waiting on the rate limiter
+func (o *Observer) RateLimitWaited(ctx context.Context, method string, wait time.Duration) {
+	budget := budgetKind(method)
+
+	trace.SpanFromContext(ctx).AddEvent("rate_limit_wait", trace.WithAttributes(
+		attribute.String("appnexus.budget", budget),
+		attribute.Float64("appnexus.rate_limit_wait", wait.Seconds()),
+	))
+
+	o.rateWait.Record(ctx, wait.Seconds(), metric.WithAttributes(attribute.String("appnexus.budget", budget)))
+}
+
+// RateUpdated keeps the latest rate limit information for the remaining
+// budget gauge
+func (o *Observer) RateUpdated(ctx context.Context, rate appnexus.Rate) {
+	if rate.ReadLimit == 0 && rate.WriteLimit == 0 {
+		return
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.rate = &rate
+}
+
+// observeRemaining reports the read and write budget left
+func (o *Observer) observeRemaining(ctx context.Context, obs metric.Int64Observer) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.rate == nil {
+		return nil
+	}
+
+	obs.Observe(int64(o.rate.ReadLimit-o.rate.Reads), metric.WithAttributes(attribute.String("appnexus.budget", "read")))
+	obs.Observe(int64(o.rate.WriteLimit-o.rate.Writes), metric.WithAttributes(attribute.String("appnexus.budget", "write")))
+	return nil
+}
+
+// budgetKind returns which AppNexus budget a request method is counted in
+func budgetKind(method string) string {
+	if method == "GET" {
+		return "read"
+	}
+
+	return "write"
+}
+
+var _ appnexus.Observer = (*Observer)(nil)
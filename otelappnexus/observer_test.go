@@ -0,0 +1,139 @@
+package otelappnexus
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/adwww/appnexus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestObserver(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	calls := 0
+	mux.HandleFunc("/segment/42", func(w http.ResponseWriter, r *http.Request) {
+		if calls++; calls == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		fmt.Fprint(w, `{"response":{"status":"OK","segment":{"id":1},
+			"dbg_info":{"reads":10,"read_limit":100,"read_limit_seconds":60,"writes":1,"write_limit":60,"write_limit_seconds":60}}}`)
+	})
+
+	mux.HandleFunc("/segment/43", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"response":{"error_id":"NOTFOUND","error":"segment not found"}}`)
+	})
+
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+
+	o, err := NewObserver(
+		WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))),
+		WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+	)
+	if err != nil {
+		t.Fatalf("NewObserver returned error: %v", err)
+	}
+
+	c, _ := appnexus.NewClient(server.URL,
+		appnexus.WithObserver(o),
+		appnexus.WithRetryPolicy(appnexus.RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond}),
+	)
+
+	if _, err := c.Segments.Get(42, 1); err != nil {
+		t.Fatalf("Segments.Get returned error: %v", err)
+	}
+
+	if _, err := c.Segments.Get(43, 1); err == nil {
+		t.Fatalf("Segments.Get expected an error")
+	}
+
+	ended := spans.Ended()
+	if len(ended) != 2 {
+		t.Fatalf("recorded %d spans, expected 2", len(ended))
+	}
+
+	ok, failed := attrs(ended[0].Attributes()), attrs(ended[1].Attributes())
+	if ok["appnexus.service"] != "segment" || ok["appnexus.member_id"] != "42" || ok["appnexus.attempts"] != "2" || ok["http.response.status_code"] != "200" {
+		t.Errorf("span attributes are %v, expected a segment call for member 42 in 2 attempts", ok)
+	}
+
+	if len(ended[0].Events()) != 1 || ended[0].Events()[0].Name != "retry" {
+		t.Errorf("span events are %v, expected a single retry", ended[0].Events())
+	}
+
+	if failed["appnexus.error_id"] != "NOTFOUND" || ended[1].Status().Code != codes.Error {
+		t.Errorf("span attributes are %v with status %v, expected a NOTFOUND error", failed, ended[1].Status())
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("Collect returned error: %v", err)
+	}
+
+	got := map[string]metricdata.Aggregation{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			got[m.Name] = m.Data
+		}
+	}
+
+	if sum := total(got["appnexus.client.requests"]); sum != 2 {
+		t.Errorf("appnexus.client.requests is %d, expected 2", sum)
+	}
+
+	if sum := total(got["appnexus.client.retries"]); sum != 1 {
+		t.Errorf("appnexus.client.retries is %d, expected 1", sum)
+	}
+
+	if h, ok := got["appnexus.client.duration"].(metricdata.Histogram[float64]); !ok || len(h.DataPoints) != 2 {
+		t.Errorf("appnexus.client.duration is %+v, expected a data point per outcome", got["appnexus.client.duration"])
+	}
+
+	remaining := map[string]int64{}
+	if g, ok := got["appnexus.client.rate_limit.remaining"].(metricdata.Gauge[int64]); ok {
+		for _, dp := range g.DataPoints {
+			budget, _ := dp.Attributes.Value("appnexus.budget")
+			remaining[budget.AsString()] = dp.Value
+		}
+	}
+
+	if remaining["read"] != 90 || remaining["write"] != 59 {
+		t.Errorf("appnexus.client.rate_limit.remaining is %v, expected 90 reads and 59 writes", remaining)
+	}
+}
+
+// attrs flattens span attributes into strings for comparison
+func attrs(kvs []attribute.KeyValue) map[string]string {
+	m := map[string]string{}
+	for _, kv := range kvs {
+		m[string(kv.Key)] = kv.Value.Emit()
+	}
+
+	return m
+}
+
+// total adds up the data points of an int64 counter
+func total(data metricdata.Aggregation) int64 {
+	var sum int64
+	if s, ok := data.(metricdata.Sum[int64]); ok {
+		for _, dp := range s.DataPoints {
+			sum += dp.Value
+		}
+	}
+
+	return sum
+}
@@ -0,0 +1,119 @@
+package appnexus
+
+import "context"
+
+// MaxPageSize is the largest number of elements AppNexus returns in a single
+// page of a List call
+const MaxPageSize = 100
+
+// pageFunc fetches the page of results described by opt
+type pageFunc[T any] func(ctx context.Context, opt *ListOptions) ([]T, *Response, error)
+
+// Iterator walks every page of a List endpoint, fetching each page only when
+// the previous one has been consumed:
+//
+//	it := c.Segments.Iter(memberID, nil)
+//	for it.Next() {
+//		segment := it.Value()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type Iterator[T any] struct {
+	ctx   context.Context
+	fetch pageFunc[T]
+	opt   ListOptions
+	page  []T
+	index int
+	value T
+	last  bool
+	err   error
+}
+
+// newIterator returns an Iterator over the pages returned by fetch, starting
+// at opt.StartElement and never requesting more than MaxPageSize elements
+func newIterator[T any](ctx context.Context, opt *ListOptions, fetch pageFunc[T]) *Iterator[T] {
+	it := &Iterator[T]{ctx: ctx, fetch: fetch}
+	if opt != nil {
+		it.opt = *opt
+	}
+
+	if it.opt.NumElements <= 0 || it.opt.NumElements > MaxPageSize {
+		it.opt.NumElements = MaxPageSize
+	}
+
+	return it
+}
+
+// Next advances the iterator to the next element, fetching the next page if
+// required. It returns false once every element has been seen, the context
+// is done or a request fails; check Err to tell these apart.
+func (it *Iterator[T]) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	if err := it.ctx.Err(); err != nil {
+		it.err = err
+		return false
+	}
+
+	for it.index >= len(it.page) {
+		if it.last {
+			return false
+		}
+
+		if !it.nextPage() {
+			return false
+		}
+	}
+
+	it.value = it.page[it.index]
+	it.index++
+	return true
+}
+
+// nextPage fetches the page following the current one
+func (it *Iterator[T]) nextPage() bool {
+	opt := it.opt
+	page, resp, err := it.fetch(it.ctx, &opt)
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	it.page = page
+	it.index = 0
+	it.opt.StartElement += len(page)
+
+	// A short page, or reaching the total count, means this was the last one:
+	it.last = len(page) < it.opt.NumElements
+	if resp != nil && resp.Obj.Count > 0 && it.opt.StartElement >= resp.Obj.Count {
+		it.last = true
+	}
+
+	return true
+}
+
+// Value returns the current element
+func (it *Iterator[T]) Value() T {
+	return it.value
+}
+
+// Err returns the error that stopped the iterator, if any
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// listAll collects every element of every page returned by fetch
+func listAll[T any](ctx context.Context, opt *ListOptions, fetch pageFunc[T]) ([]T, error) {
+	var all []T
+
+	it := newIterator(ctx, opt, fetch)
+	for it.Next() {
+		all = append(all, it.Value())
+	}
+
+	return all, it.Err()
+}
@@ -0,0 +1,116 @@
+package appnexus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+// fakePages serves total numbered elements in pages, like an AppNexus List
+func fakePages(total int, calls *[]ListOptions) pageFunc[int] {
+	return func(ctx context.Context, opt *ListOptions) ([]int, *Response, error) {
+		*calls = append(*calls, *opt)
+
+		var page []int
+		for i := opt.StartElement; i < total && len(page) < opt.NumElements; i++ {
+			page = append(page, i)
+		}
+
+		resp := &Response{}
+		resp.Obj.Count = total
+		resp.Obj.StartElement = opt.StartElement
+		resp.Obj.NumElements = len(page)
+		return page, resp, nil
+	}
+}
+
+func TestIterator_AllPages(t *testing.T) {
+	var calls []ListOptions
+
+	all, err := listAll(context.Background(), &ListOptions{NumElements: 500}, fakePages(250, &calls))
+	if err != nil {
+		t.Fatalf("listAll returned error: %v", err)
+	}
+
+	if len(all) != 250 || all[0] != 0 || all[249] != 249 {
+		t.Errorf("listAll returned %d elements, expected 250 in order", len(all))
+	}
+
+	if len(calls) != 3 {
+		t.Fatalf("listAll fetched %d pages, expected 3", len(calls))
+	}
+
+	for i, opt := range calls {
+		if opt.StartElement != i*MaxPageSize || opt.NumElements != MaxPageSize {
+			t.Errorf("page %d requested %+v, expected start %d with %d elements", i, opt, i*MaxPageSize, MaxPageSize)
+		}
+	}
+}
+
+func TestIterator_StartElement(t *testing.T) {
+	var calls []ListOptions
+
+	it := newIterator(context.Background(), &ListOptions{StartElement: 5, NumElements: 2}, fakePages(8, &calls))
+
+	var actual []int
+	for it.Next() {
+		actual = append(actual, it.Value())
+	}
+
+	if fmt.Sprint(actual) != "[5 6 7]" || it.Err() != nil {
+		t.Errorf("Iterator returned %v (%v), expected [5 6 7]", actual, it.Err())
+	}
+
+	if len(calls) != 2 {
+		t.Errorf("Iterator fetched %d pages, expected 2", len(calls))
+	}
+}
+
+func TestIterator_ContextCancelled(t *testing.T) {
+	var calls []ListOptions
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	it := newIterator(ctx, nil, fakePages(250, &calls))
+	for i := 0; it.Next(); i++ {
+		if i == 10 {
+			cancel()
+		}
+	}
+
+	if !errors.Is(it.Err(), context.Canceled) {
+		t.Errorf("Iterator stopped with %v, expected %v", it.Err(), context.Canceled)
+	}
+
+	if len(calls) != 1 {
+		t.Errorf("Iterator fetched %d pages, expected 1", len(calls))
+	}
+}
+
+func TestSegmentService_ListAll(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/segment/1", func(w http.ResponseWriter, r *http.Request) {
+		start, _ := strconv.Atoi(r.URL.Query().Get("start_element"))
+		if start == 0 {
+			fmt.Fprint(w, `{"response":{"status":"OK","count":3,"start_element":0,"num_elements":2,
+				"segments":[{"id":1},{"id":2}]}}`)
+			return
+		}
+		fmt.Fprint(w, `{"response":{"status":"OK","count":3,"start_element":2,"num_elements":2,
+			"segments":[{"id":3}]}}`)
+	})
+
+	actual, err := client.Segments.ListAll(1, &ListOptions{NumElements: 2})
+	if err != nil {
+		t.Fatalf("Segments.ListAll returned error: %v", err)
+	}
+
+	if len(actual) != 3 || actual[2].ID != 3 {
+		t.Errorf("Segments.ListAll returned %+v, expected segments 1 to 3", actual)
+	}
+}
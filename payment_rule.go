@@ -0,0 +1,230 @@
+package appnexus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// PaymentRuleService handles all requests to the payment rule service API
+type PaymentRuleService struct {
+	*Response
+	client *Client
+}
+
+// Payment rule pricing types: a share of revenue or a fixed CPM, paid to
+// the publisher or, for owner types, kept by the owning member
+const (
+	PricingTypeRevshare      = "revshare"
+	PricingTypeCPM           = "cpm"
+	PricingTypeOwnerRevshare = "owner_revshare"
+	PricingTypeOwnerCPM      = "owner_cpm"
+)
+
+// PaymentRule decides how a publisher is paid for the impressions it sells.
+// A publisher's base rule, given by Publisher.BasePaymentRuleID, applies
+// when no other rule matches.
+type PaymentRule struct {
+	ID                  int         `json:"id,omitempty"`
+	Code                string      `json:"code,omitempty"`
+	Name                string      `json:"name,omitempty"`
+	Description         string      `json:"description,omitempty"`
+	State               string      `json:"state,omitempty"`
+	PublisherID         int         `json:"publisher_id,omitempty"`
+	PricingType         string      `json:"pricing_type,omitempty"`
+	Revshare            *float64    `json:"revshare,omitempty"`
+	CostCPM             *float64    `json:"cost_cpm,omitempty"`
+	Priority            int         `json:"priority,omitempty"`
+	ProfileID           int         `json:"profile_id,omitempty"`
+	StartDate           string      `json:"start_date,omitempty"`
+	EndDate             string      `json:"end_date,omitempty"`
+	Timezone            string      `json:"timezone,omitempty"`
+	ApplyCostOnDefault  bool        `json:"apply_cost_on_default,omitempty"`
+	DemandFilterAction  string      `json:"demand_filter_action,omitempty"`
+	FilteredAdvertisers []ObjectRef `json:"filtered_advertisers,omitempty"`
+	FilteredLineItems   []ObjectRef `json:"filtered_line_items,omitempty"`
+	FilteredCampaigns   []ObjectRef `json:"filtered_campaigns,omitempty"`
+	LastModified        string      `json:"last_modified,omitempty"`
+}
+
+type paymentRuleResponse struct {
+	*http.Response
+	Obj struct {
+		PaymentRule  PaymentRule   `json:"payment-rule,omitempty"`
+		PaymentRules []PaymentRule `json:"payment-rules,omitempty"`
+		Error        string        `json:"error"`
+		Status       string        `json:"status"`
+		Service      string        `json:"service"`
+		Rate         Rate          `json:"dbg_info"`
+	} `json:"response"`
+}
+
+// Get a payment rule from the payment rule service by Publisher ID and
+// Payment Rule ID
+func (s *PaymentRuleService) Get(publisherID int, paymentRuleID int) (*PaymentRule, error) {
+	return s.GetContext(context.Background(), publisherID, paymentRuleID)
+}
+
+// GetContext is like Get but honours cancellation and deadlines on ctx
+func (s *PaymentRuleService) GetContext(ctx context.Context, publisherID int, paymentRuleID int) (*PaymentRule, error) {
+
+	path := fmt.Sprintf("payment-rule?publisher_id=%d&id=%d", publisherID, paymentRuleID)
+	req, err := s.client.newRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &paymentRuleResponse{}
+	_, err = s.client.do(req, r)
+	if err != nil {
+		return nil, err
+	}
+
+	paymentRule := &r.Obj.PaymentRule
+	return paymentRule, nil
+}
+
+// List the payment rules of a publisher
+func (s *PaymentRuleService) List(publisherID int, opt *ListOptions) ([]PaymentRule, *Response, error) {
+	return s.ListContext(context.Background(), publisherID, opt)
+}
+
+// ListContext is like List but honours cancellation and deadlines on ctx
+func (s *PaymentRuleService) ListContext(ctx context.Context, publisherID int, opt *ListOptions) ([]PaymentRule, *Response, error) {
+	u, err := addOptions(fmt.Sprintf("payment-rule?publisher_id=%d", publisherID), opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.newRequest(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	paymentRules := &paymentRuleResponse{}
+	resp, err := s.client.do(req, paymentRules)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return paymentRules.Obj.PaymentRules, resp, err
+}
+
+// Iter returns an Iterator over every payment rule of the publisher
+func (s *PaymentRuleService) Iter(publisherID int, opt *ListOptions) *Iterator[PaymentRule] {
+	return s.IterContext(context.Background(), publisherID, opt)
+}
+
+// IterContext is like Iter but stops once ctx is done
+func (s *PaymentRuleService) IterContext(ctx context.Context, publisherID int, opt *ListOptions) *Iterator[PaymentRule] {
+	return newIterator(ctx, opt, func(ctx context.Context, opt *ListOptions) ([]PaymentRule, *Response, error) {
+		return s.ListContext(ctx, publisherID, opt)
+	})
+}
+
+// ListAll fetches every payment rule of the publisher, walking through all
+// pages
+func (s *PaymentRuleService) ListAll(publisherID int, opt *ListOptions) ([]PaymentRule, error) {
+	return s.ListAllContext(context.Background(), publisherID, opt)
+}
+
+// ListAllContext is like ListAll but honours cancellation and deadlines on
+// ctx
+func (s *PaymentRuleService) ListAllContext(ctx context.Context, publisherID int, opt *ListOptions) ([]PaymentRule, error) {
+	return listAll(ctx, opt, func(ctx context.Context, opt *ListOptions) ([]PaymentRule, *Response, error) {
+		return s.ListContext(ctx, publisherID, opt)
+	})
+}
+
+// Add a new payment rule to a publisher
+func (s *PaymentRuleService) Add(publisherID int, item *PaymentRule) (*Response, error) {
+	return s.AddContext(context.Background(), publisherID, item)
+}
+
+// AddContext is like Add but honours cancellation and deadlines on ctx
+func (s *PaymentRuleService) AddContext(ctx context.Context, publisherID int, item *PaymentRule) (*Response, error) {
+
+	data := struct {
+		PaymentRule `json:"payment-rule"`
+	}{*item}
+
+	req, err := s.client.newRequest(ctx, "POST", fmt.Sprintf("payment-rule?publisher_id=%d", publisherID), data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Response{}
+	resp, err := s.client.do(req, result)
+	if err != nil {
+		return resp, err
+	}
+
+	item.ID = result.Obj.ID
+	return result, nil
+}
+
+// Update an existing payment rule with new data
+func (s *PaymentRuleService) Update(publisherID int, item PaymentRule) (*Response, error) {
+	return s.UpdateContext(context.Background(), publisherID, item)
+}
+
+// UpdateContext is like Update but honours cancellation and deadlines on ctx
+func (s *PaymentRuleService) UpdateContext(ctx context.Context, publisherID int, item PaymentRule) (*Response, error) {
+
+	data := struct {
+		PaymentRule `json:"payment-rule"`
+	}{item}
+
+	if item.ID < 1 {
+		return nil, errors.New("Update PaymentRule requires a payment rule to have an ID already")
+	}
+
+	path := fmt.Sprintf("payment-rule?publisher_id=%d&id=%d", publisherID, item.ID)
+	req, err := s.client.newRequest(ctx, "PUT", path, data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Response{}
+	resp, err := s.client.do(req, result)
+	if err != nil {
+		return resp, err
+	}
+
+	return result, nil
+}
+
+// Delete the specified payment rule
+func (s *PaymentRuleService) Delete(publisherID int, item PaymentRule) error {
+	return s.DeleteContext(context.Background(), publisherID, item)
+}
+
+// DeleteContext is like Delete but honours cancellation and deadlines on ctx
+func (s *PaymentRuleService) DeleteContext(ctx context.Context, publisherID int, item PaymentRule) error {
+
+	if item.ID < 1 {
+		return errors.New("Delete PaymentRule requires a payment rule to have an ID already")
+	}
+
+	path := fmt.Sprintf("payment-rule?publisher_id=%d&id=%d", publisherID, item.ID)
+	req, err := s.client.newRequest(ctx, "DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.do(req, nil)
+	return err
+}
+
+// SetBase makes a payment rule the base rule of its publisher, paying for
+// all impressions no other rule matches
+func (s *PaymentRuleService) SetBase(memberID int, publisherID int, paymentRuleID int) (*Response, error) {
+	return s.SetBaseContext(context.Background(), memberID, publisherID, paymentRuleID)
+}
+
+// SetBaseContext is like SetBase but honours cancellation and deadlines on
+// ctx
+func (s *PaymentRuleService) SetBaseContext(ctx context.Context, memberID int, publisherID int, paymentRuleID int) (*Response, error) {
+	return s.client.Publishers.UpdateContext(ctx, memberID, Publisher{ID: publisherID, BasePaymentRuleID: paymentRuleID})
+}
@@ -0,0 +1,149 @@
+package appnexus
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestPaymentRuleService_Get(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/payment-rule", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("publisher_id") != "51" || r.URL.Query().Get("id") != "91" {
+			t.Errorf("PaymentRules.Get requested %s, expected publisher_id=51&id=91", r.URL.RawQuery)
+		}
+
+		fmt.Fprint(w, `{"response":
+            {"status":"OK",
+            "payment-rule": {
+                "id": 91,
+                "name": "Base rev share",
+                "pricing_type": "revshare",
+                "revshare": 0.7,
+                "cost_cpm": null,
+                "priority": 5
+            }}}`)
+	})
+
+	actual, err := client.PaymentRules.Get(51, 91)
+	if err != nil {
+		t.Fatalf("PaymentRules.Get returned error: %v", err)
+	}
+
+	if actual.PricingType != PricingTypeRevshare || actual.Revshare == nil || *actual.Revshare != 0.7 || actual.CostCPM != nil {
+		t.Errorf("PaymentRules.Get returned %+v", actual)
+	}
+}
+
+func TestPaymentRuleService_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/payment-rule", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"response": {"status":"OK", "count": 2, "payment-rules": [{"id": 91}, {"id": 92, "pricing_type": "owner_cpm"}]}}`)
+	})
+
+	actual, _, err := client.PaymentRules.List(51, nil)
+	if err != nil {
+		t.Errorf("PaymentRules.List returned error: %v", err)
+	}
+
+	if len(actual) != 2 || actual[1].PricingType != PricingTypeOwnerCPM {
+		t.Errorf("PaymentRules.List returned %+v", actual)
+	}
+}
+
+func TestPaymentRuleService_Add(t *testing.T) {
+	setup()
+	defer teardown()
+
+	cpm := 1.5
+	data := PaymentRule{Name: "Fixed CPM", PricingType: PricingTypeCPM, CostCPM: &cpm}
+
+	mux.HandleFunc("/payment-rule", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Query().Get("publisher_id") != "51" {
+			t.Errorf("PaymentRules.Add sent %s %s", r.Method, r.URL)
+		}
+
+		var body struct {
+			PaymentRule PaymentRule `json:"payment-rule"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.PaymentRule.CostCPM == nil || *body.PaymentRule.CostCPM != 1.5 {
+			t.Errorf("PaymentRules.Add sent %+v (%v)", body, err)
+		}
+
+		fmt.Fprint(w, `{"response": {"status":"OK", "id": 93 }}`)
+	})
+
+	actual, err := client.PaymentRules.Add(51, &data)
+	if err != nil {
+		t.Errorf("PaymentRules.Add returned error: %v", err)
+	}
+
+	if actual.Obj.ID != 93 || data.ID != 93 {
+		t.Errorf("PaymentRules.Add returned %+v and set ID %d, expected 93", actual.Obj, data.ID)
+	}
+}
+
+func TestPaymentRuleService_SetBase(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/publisher", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Query().Get("member_id") != "1" || r.URL.Query().Get("id") != "51" {
+			t.Errorf("PaymentRules.SetBase sent %s %s", r.Method, r.URL)
+		}
+
+		var body struct {
+			Publisher Publisher `json:"publisher"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Publisher.BasePaymentRuleID != 93 {
+			t.Errorf("PaymentRules.SetBase sent %+v (%v)", body, err)
+		}
+
+		fmt.Fprint(w, `{"response": {"status":"OK" }}`)
+	})
+
+	if _, err := client.PaymentRules.SetBase(1, 51, 93); err != nil {
+		t.Errorf("PaymentRules.SetBase returned error: %v", err)
+	}
+}
+
+func TestPaymentRuleService_Update(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/payment-rule", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Query().Get("id") != "91" {
+			t.Errorf("PaymentRules.Update sent %s %s", r.Method, r.URL)
+		}
+
+		fmt.Fprint(w, `{"response": {"status":"OK" }}`)
+	})
+
+	if _, err := client.PaymentRules.Update(51, PaymentRule{ID: 91, Priority: 3}); err != nil {
+		t.Errorf("PaymentRules.Update returned error: %v", err)
+	}
+
+	if _, err := client.PaymentRules.Update(51, PaymentRule{}); err == nil {
+		t.Error("PaymentRules.Update accepted a payment rule without an ID")
+	}
+}
+
+func TestPaymentRuleService_Delete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/payment-rule", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" || r.URL.Query().Get("id") != "91" {
+			t.Errorf("PaymentRules.Delete sent %s %s", r.Method, r.URL)
+		}
+	})
+
+	if err := client.PaymentRules.Delete(51, PaymentRule{ID: 91}); err != nil {
+		t.Errorf("PaymentRules.Delete returned error: %v", err)
+	}
+}
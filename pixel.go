@@ -0,0 +1,176 @@
+package appnexus
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Hosts serving segment pixels
+const (
+	PixelHost       = "ib.adnxs.com"
+	SecurePixelHost = "secure.adnxs.com"
+)
+
+// PixelType is the value of a segment pixel's t parameter, telling AppNexus
+// how the pixel is embedded in the page
+type PixelType int
+
+// Pixel types
+const (
+	PixelTypeJS    PixelType = 1
+	PixelTypeImage PixelType = 2
+)
+
+// pixelSegment is a segment added to or removed from users by a pixel
+type pixelSegment struct {
+	id       int
+	code     string
+	memberID int
+	value    int
+}
+
+// SegmentPixel builds the URL and HTML tags of a segment pixel, which adds
+// the users who load it to segments and removes them from others:
+//
+//	p := appnexus.SegmentPixel{Secure: true}
+//	p.Add(carsSegment, 0)
+//	p.Remove(bikesSegment)
+//	tag, err := p.ImageTag()
+type SegmentPixel struct {
+	// Secure serves the pixel over HTTPS from SecurePixelHost
+	Secure bool
+
+	// Host overrides the host the pixel is served from
+	Host string
+
+	// ExpireMinutes overrides how long users stay in the added segments
+	ExpireMinutes int
+
+	// Redirect is loaded by the user's browser once the pixel has fired,
+	// for example another pixel's URL. See Then.
+	Redirect string
+
+	add    []pixelSegment
+	remove []pixelSegment
+}
+
+// Add adds users to s, given by ID or else by code and member ID. A value
+// greater than zero is stored with the user's membership.
+func (p *SegmentPixel) Add(s Segment, value int) {
+	p.add = append(p.add, pixelSegment{id: s.ID, code: s.Code, memberID: s.MemberID, value: value})
+}
+
+// Remove removes users from s, given by ID or else by code and member ID
+func (p *SegmentPixel) Remove(s Segment) {
+	p.remove = append(p.remove, pixelSegment{id: s.ID, code: s.Code, memberID: s.MemberID})
+}
+
+// Then chains next after this pixel, so that users' browsers load it once
+// this one has fired
+func (p *SegmentPixel) Then(next SegmentPixel) error {
+	u, err := next.URL(PixelTypeImage)
+	if err != nil {
+		return err
+	}
+
+	p.Redirect = u
+	return nil
+}
+
+// URL returns the pixel's URL for embedding as the given type
+func (p SegmentPixel) URL(t PixelType) (string, error) {
+	if len(p.add) == 0 && len(p.remove) == 0 {
+		return "", errors.New("Segment pixel requires at least one segment to add or remove")
+	}
+
+	member := 0
+	var params []string
+
+	for _, list := range []struct {
+		name     string
+		segments []pixelSegment
+	}{{"add", p.add}, {"remove", p.remove}} {
+		var ids, codes []string
+
+		for _, s := range list.segments {
+			switch {
+			case s.id > 0:
+				ids = append(ids, s.format(strconv.Itoa(s.id)))
+			case s.code != "" && s.memberID > 0:
+				if member != 0 && member != s.memberID {
+					return "", fmt.Errorf("Segment pixel codes must belong to one member, not both %d and %d", member, s.memberID)
+				}
+				member = s.memberID
+				codes = append(codes, s.format(url.QueryEscape(s.code)))
+			default:
+				return "", errors.New("Segment pixel segments require an ID, or a code and member ID")
+			}
+		}
+
+		if len(ids) > 0 {
+			params = append(params, list.name+"="+strings.Join(ids, ","))
+		}
+
+		if len(codes) > 0 {
+			params = append(params, list.name+"_code="+strings.Join(codes, ","))
+		}
+	}
+
+	if member > 0 {
+		params = append(params, "member="+strconv.Itoa(member))
+	}
+
+	if p.ExpireMinutes > 0 {
+		params = append(params, "expire_minutes="+strconv.Itoa(p.ExpireMinutes))
+	}
+
+	params = append(params, "t="+strconv.Itoa(int(t)))
+
+	if p.Redirect != "" {
+		params = append(params, "redir="+url.QueryEscape(p.Redirect))
+	}
+
+	scheme, host := "http", PixelHost
+	if p.Secure {
+		scheme, host = "https", SecurePixelHost
+	}
+
+	if p.Host != "" {
+		host = p.Host
+	}
+
+	return scheme + "://" + host + "/seg?" + strings.Join(params, "&"), nil
+}
+
+// ImageTag returns an HTML image tag firing the pixel
+func (p SegmentPixel) ImageTag() (string, error) {
+	u, err := p.URL(PixelTypeImage)
+	if err != nil {
+		return "", err
+	}
+
+	return `<img src="` + html.EscapeString(u) + `" width="1" height="1" />`, nil
+}
+
+// JSTag returns an HTML script tag firing the pixel
+func (p SegmentPixel) JSTag() (string, error) {
+	u, err := p.URL(PixelTypeJS)
+	if err != nil {
+		return "", err
+	}
+
+	return `<script src="` + html.EscapeString(u) + `" type="text/javascript"></script>`, nil
+}
+
+// format appends the segment's value to its ID or code, when it has one
+func (s pixelSegment) format(key string) string {
+	if s.value > 0 {
+		return key + ":" + strconv.Itoa(s.value)
+	}
+
+	return key
+}
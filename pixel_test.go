@@ -0,0 +1,72 @@
+package appnexus
+
+import "testing"
+
+func TestSegmentPixel_URL(t *testing.T) {
+	p := SegmentPixel{}
+	p.Add(Segment{ID: 123}, 0)
+	p.Add(Segment{ID: 456}, 5)
+	p.Add(Segment{Code: "used cars", MemberID: 7}, 0)
+	p.Remove(Segment{ID: 789})
+
+	actual, err := p.URL(PixelTypeImage)
+	if err != nil {
+		t.Fatalf("URL returned error: %v", err)
+	}
+
+	if expected := "http://ib.adnxs.com/seg?add=123,456:5&add_code=used+cars&remove=789&member=7&t=2"; actual != expected {
+		t.Errorf("URL is %v, expected %v", actual, expected)
+	}
+}
+
+func TestSegmentPixel_Tags(t *testing.T) {
+	next := SegmentPixel{Secure: true}
+	next.Add(Segment{ID: 2}, 0)
+
+	p := SegmentPixel{Secure: true, ExpireMinutes: 1440}
+	p.Add(Segment{ID: 1}, 0)
+	if err := p.Then(next); err != nil {
+		t.Fatalf("Then returned error: %v", err)
+	}
+
+	img, err := p.ImageTag()
+	if err != nil {
+		t.Fatalf("ImageTag returned error: %v", err)
+	}
+
+	expected := `<img src="https://secure.adnxs.com/seg?add=1&amp;expire_minutes=1440&amp;t=2&amp;redir=https%3A%2F%2Fsecure.adnxs.com%2Fseg%3Fadd%3D2%26t%3D2" width="1" height="1" />`
+	if img != expected {
+		t.Errorf("ImageTag is %v, expected %v", img, expected)
+	}
+
+	p = SegmentPixel{Host: "pixels.example.com"}
+	p.Remove(Segment{ID: 3})
+
+	js, err := p.JSTag()
+	if err != nil {
+		t.Fatalf("JSTag returned error: %v", err)
+	}
+
+	if expected := `<script src="http://pixels.example.com/seg?remove=3&amp;t=1" type="text/javascript"></script>`; js != expected {
+		t.Errorf("JSTag is %v, expected %v", js, expected)
+	}
+}
+
+func TestSegmentPixel_Invalid(t *testing.T) {
+	if _, err := (SegmentPixel{}).URL(PixelTypeImage); err == nil {
+		t.Errorf("URL expected an error for a pixel without segments")
+	}
+
+	p := SegmentPixel{}
+	p.Add(Segment{Code: "cars"}, 0)
+	if _, err := p.URL(PixelTypeImage); err == nil {
+		t.Errorf("URL expected an error for a code without a member ID")
+	}
+
+	p = SegmentPixel{}
+	p.Add(Segment{Code: "cars", MemberID: 1}, 0)
+	p.Add(Segment{Code: "bikes", MemberID: 2}, 0)
+	if _, err := p.URL(PixelTypeImage); err == nil {
+		t.Errorf("URL expected an error for codes of different members")
+	}
+}
@@ -0,0 +1,307 @@
+package appnexus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+)
+
+// PlacementService handles all requests to the placement service API
+type PlacementService struct {
+	*Response
+	client *Client
+}
+
+// PlacementSize is an ad size a placement accepts
+type PlacementSize struct {
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// Placement is a slot on a publisher's site that ads are served into
+type Placement struct {
+	ID                     int             `json:"id,omitempty"`
+	Code                   string          `json:"code,omitempty"`
+	Name                   string          `json:"name,omitempty"`
+	State                  string          `json:"state,omitempty"`
+	PublisherID            int             `json:"publisher_id,omitempty"`
+	SiteID                 int             `json:"site_id,omitempty"`
+	Width                  int             `json:"width,omitempty"`
+	Height                 int             `json:"height,omitempty"`
+	Sizes                  []PlacementSize `json:"sizes,omitempty"`
+	IsResizable            bool            `json:"is_resizable,omitempty"`
+	ReservePrice           float64         `json:"reserve_price,omitempty"`
+	DefaultCreatives       []ObjectRef     `json:"default_creatives,omitempty"`
+	DefaultCreativeID      int             `json:"default_creative_id,omitempty"`
+	SupportedMediaTypes    []ObjectRef     `json:"supported_media_types,omitempty"`
+	SupportedMediaSubtypes []ObjectRef     `json:"supported_media_subtypes,omitempty"`
+	IntendedAudience       string          `json:"intended_audience,omitempty"`
+	LastModified           string          `json:"last_modified,omitempty"`
+}
+
+type placementResponse struct {
+	*http.Response
+	Obj struct {
+		Placement  Placement   `json:"placement,omitempty"`
+		Placements []Placement `json:"placements,omitempty"`
+		Error      string      `json:"error"`
+		Status     string      `json:"status"`
+		Service    string      `json:"service"`
+		Rate       Rate        `json:"dbg_info"`
+	} `json:"response"`
+}
+
+// size returns the size the placement's tags are written for: its own, or
+// else the first it accepts
+func (p Placement) size() (PlacementSize, error) {
+	if p.Width > 0 && p.Height > 0 {
+		return PlacementSize{p.Width, p.Height}, nil
+	}
+
+	if len(p.Sizes) > 0 {
+		return p.Sizes[0], nil
+	}
+
+	return PlacementSize{}, errors.New("Placement tag requires a placement with a size")
+}
+
+// tagURL returns the URL of the placement's ad call to the given endpoint
+func (p Placement) tagURL(endpoint string, secure bool) (string, PlacementSize, error) {
+	if p.ID < 1 {
+		return "", PlacementSize{}, errors.New("Placement tag requires a placement to have an ID already")
+	}
+
+	size, err := p.size()
+	if err != nil {
+		return "", size, err
+	}
+
+	scheme, host := "http", PixelHost
+	if secure {
+		scheme, host = "https", SecurePixelHost
+	}
+
+	return fmt.Sprintf("%s://%s/%s?id=%d&size=%dx%d", scheme, host, endpoint, p.ID, size.Width, size.Height), size, nil
+}
+
+// JSTag returns the JavaScript tag snippet serving the placement
+func (p Placement) JSTag(secure bool) (string, error) {
+	u, _, err := p.tagURL("ttj", secure)
+	if err != nil {
+		return "", err
+	}
+
+	return `<script src="` + html.EscapeString(u) + `" type="text/javascript"></script>`, nil
+}
+
+// IframeTag returns the iframe tag snippet serving the placement
+func (p Placement) IframeTag(secure bool) (string, error) {
+	u, size, err := p.tagURL("tt", secure)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(`<iframe src="%s" width="%d" height="%d" marginwidth="0" marginheight="0" frameborder="0" scrolling="no"></iframe>`,
+		html.EscapeString(u), size.Width, size.Height), nil
+}
+
+// Get a placement from the placement service by Publisher ID and Placement
+// ID
+func (s *PlacementService) Get(publisherID int, placementID int) (*Placement, error) {
+	return s.GetContext(context.Background(), publisherID, placementID)
+}
+
+// GetContext is like Get but honours cancellation and deadlines on ctx
+func (s *PlacementService) GetContext(ctx context.Context, publisherID int, placementID int) (*Placement, error) {
+
+	path := fmt.Sprintf("placement?publisher_id=%d&id=%d", publisherID, placementID)
+	req, err := s.client.newRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &placementResponse{}
+	_, err = s.client.do(req, r)
+	if err != nil {
+		return nil, err
+	}
+
+	placement := &r.Obj.Placement
+	return placement, nil
+}
+
+// List the placements of a publisher
+func (s *PlacementService) List(publisherID int, opt *ListOptions) ([]Placement, *Response, error) {
+	return s.ListContext(context.Background(), publisherID, opt)
+}
+
+// ListContext is like List but honours cancellation and deadlines on ctx
+func (s *PlacementService) ListContext(ctx context.Context, publisherID int, opt *ListOptions) ([]Placement, *Response, error) {
+	return s.list(ctx, fmt.Sprintf("placement?publisher_id=%d", publisherID), opt)
+}
+
+// ListBySite lists the placements of a publisher's site
+func (s *PlacementService) ListBySite(publisherID int, siteID int, opt *ListOptions) ([]Placement, *Response, error) {
+	return s.ListBySiteContext(context.Background(), publisherID, siteID, opt)
+}
+
+// ListBySiteContext is like ListBySite but honours cancellation and
+// deadlines on ctx
+func (s *PlacementService) ListBySiteContext(ctx context.Context, publisherID int, siteID int, opt *ListOptions) ([]Placement, *Response, error) {
+	return s.list(ctx, fmt.Sprintf("placement?publisher_id=%d&site_id=%d", publisherID, siteID), opt)
+}
+
+func (s *PlacementService) list(ctx context.Context, path string, opt *ListOptions) ([]Placement, *Response, error) {
+	u, err := addOptions(path, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.newRequest(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	placements := &placementResponse{}
+	resp, err := s.client.do(req, placements)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return placements.Obj.Placements, resp, err
+}
+
+// Iter returns an Iterator over every placement of the publisher
+func (s *PlacementService) Iter(publisherID int, opt *ListOptions) *Iterator[Placement] {
+	return s.IterContext(context.Background(), publisherID, opt)
+}
+
+// IterContext is like Iter but stops once ctx is done
+func (s *PlacementService) IterContext(ctx context.Context, publisherID int, opt *ListOptions) *Iterator[Placement] {
+	return newIterator(ctx, opt, func(ctx context.Context, opt *ListOptions) ([]Placement, *Response, error) {
+		return s.ListContext(ctx, publisherID, opt)
+	})
+}
+
+// ListAll fetches every placement of the publisher, walking through all
+// pages
+func (s *PlacementService) ListAll(publisherID int, opt *ListOptions) ([]Placement, error) {
+	return s.ListAllContext(context.Background(), publisherID, opt)
+}
+
+// ListAllContext is like ListAll but honours cancellation and deadlines on
+// ctx
+func (s *PlacementService) ListAllContext(ctx context.Context, publisherID int, opt *ListOptions) ([]Placement, error) {
+	return listAll(ctx, opt, func(ctx context.Context, opt *ListOptions) ([]Placement, *Response, error) {
+		return s.ListContext(ctx, publisherID, opt)
+	})
+}
+
+// IterBySite returns an Iterator over every placement of the publisher's
+// site
+func (s *PlacementService) IterBySite(publisherID int, siteID int, opt *ListOptions) *Iterator[Placement] {
+	return s.IterBySiteContext(context.Background(), publisherID, siteID, opt)
+}
+
+// IterBySiteContext is like IterBySite but stops once ctx is done
+func (s *PlacementService) IterBySiteContext(ctx context.Context, publisherID int, siteID int, opt *ListOptions) *Iterator[Placement] {
+	return newIterator(ctx, opt, func(ctx context.Context, opt *ListOptions) ([]Placement, *Response, error) {
+		return s.ListBySiteContext(ctx, publisherID, siteID, opt)
+	})
+}
+
+// ListAllBySite fetches every placement of the publisher's site, walking
+// through all pages
+func (s *PlacementService) ListAllBySite(publisherID int, siteID int, opt *ListOptions) ([]Placement, error) {
+	return s.ListAllBySiteContext(context.Background(), publisherID, siteID, opt)
+}
+
+// ListAllBySiteContext is like ListAllBySite but honours cancellation and
+// deadlines on ctx
+func (s *PlacementService) ListAllBySiteContext(ctx context.Context, publisherID int, siteID int, opt *ListOptions) ([]Placement, error) {
+	return listAll(ctx, opt, func(ctx context.Context, opt *ListOptions) ([]Placement, *Response, error) {
+		return s.ListBySiteContext(ctx, publisherID, siteID, opt)
+	})
+}
+
+// Add a new placement to a publisher, within the site given by its SiteID
+func (s *PlacementService) Add(publisherID int, item *Placement) (*Response, error) {
+	return s.AddContext(context.Background(), publisherID, item)
+}
+
+// AddContext is like Add but honours cancellation and deadlines on ctx
+func (s *PlacementService) AddContext(ctx context.Context, publisherID int, item *Placement) (*Response, error) {
+
+	data := struct {
+		Placement `json:"placement"`
+	}{*item}
+
+	req, err := s.client.newRequest(ctx, "POST", fmt.Sprintf("placement?publisher_id=%d", publisherID), data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Response{}
+	resp, err := s.client.do(req, result)
+	if err != nil {
+		return resp, err
+	}
+
+	item.ID = result.Obj.ID
+	return result, nil
+}
+
+// Update an existing placement with new data
+func (s *PlacementService) Update(publisherID int, item Placement) (*Response, error) {
+	return s.UpdateContext(context.Background(), publisherID, item)
+}
+
+// UpdateContext is like Update but honours cancellation and deadlines on ctx
+func (s *PlacementService) UpdateContext(ctx context.Context, publisherID int, item Placement) (*Response, error) {
+
+	data := struct {
+		Placement `json:"placement"`
+	}{item}
+
+	if item.ID < 1 {
+		return nil, errors.New("Update Placement requires a placement to have an ID already")
+	}
+
+	path := fmt.Sprintf("placement?publisher_id=%d&id=%d", publisherID, item.ID)
+	req, err := s.client.newRequest(ctx, "PUT", path, data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Response{}
+	resp, err := s.client.do(req, result)
+	if err != nil {
+		return resp, err
+	}
+
+	return result, nil
+}
+
+// Delete the specified placement
+func (s *PlacementService) Delete(publisherID int, item Placement) error {
+	return s.DeleteContext(context.Background(), publisherID, item)
+}
+
+// DeleteContext is like Delete but honours cancellation and deadlines on ctx
+func (s *PlacementService) DeleteContext(ctx context.Context, publisherID int, item Placement) error {
+
+	if item.ID < 1 {
+		return errors.New("Delete Placement requires a placement to have an ID already")
+	}
+
+	path := fmt.Sprintf("placement?publisher_id=%d&id=%d", publisherID, item.ID)
+	req, err := s.client.newRequest(ctx, "DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.do(req, nil)
+	return err
+}
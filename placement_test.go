@@ -0,0 +1,193 @@
+package appnexus
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestPlacementService_Get(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/placement", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("publisher_id") != "51" || r.URL.Query().Get("id") != "71" {
+			t.Errorf("Placements.Get requested %s, expected publisher_id=51&id=71", r.URL.RawQuery)
+		}
+
+		fmt.Fprint(w, `{"response":
+            {"status":"OK",
+            "placement": {
+                "id": 71,
+                "name": "Homepage MPU",
+                "site_id": 61,
+                "width": 300,
+                "height": 250,
+                "sizes": [{"width": 300, "height": 250}, {"width": 300, "height": 600}],
+                "reserve_price": 0.75,
+                "default_creatives": [{"id": 81}],
+                "supported_media_types": [{"id": 1, "name": "Banner"}]
+            }}}`)
+	})
+
+	actual, err := client.Placements.Get(51, 71)
+	if err != nil {
+		t.Fatalf("Placements.Get returned error: %v", err)
+	}
+
+	if actual.SiteID != 61 || actual.ReservePrice != 0.75 || len(actual.Sizes) != 2 || actual.Sizes[1].Height != 600 {
+		t.Errorf("Placements.Get returned %+v", actual)
+	}
+
+	if len(actual.DefaultCreatives) != 1 || actual.DefaultCreatives[0].ID != 81 || actual.SupportedMediaTypes[0].Name != "Banner" {
+		t.Errorf("Placements.Get returned default creatives %+v and media types %+v", actual.DefaultCreatives, actual.SupportedMediaTypes)
+	}
+}
+
+func TestPlacementService_ListBySite(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/placement", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("publisher_id") != "51" || r.URL.Query().Get("site_id") != "61" {
+			t.Errorf("Placements.ListBySite requested %s, expected publisher_id=51&site_id=61", r.URL.RawQuery)
+		}
+
+		fmt.Fprint(w, `{"response": {"status":"OK", "count": 2, "placements": [{"id": 71}, {"id": 72}]}}`)
+	})
+
+	actual, _, err := client.Placements.ListBySite(51, 61, nil)
+	if err != nil {
+		t.Errorf("Placements.ListBySite returned error: %v", err)
+	}
+
+	if len(actual) != 2 || actual[1].ID != 72 {
+		t.Errorf("Placements.ListBySite returned %+v", actual)
+	}
+}
+
+func TestPlacementService_ListAllBySite(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/placement", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("publisher_id") != "5" || r.URL.Query().Get("site_id") != "9" {
+			t.Errorf("Placements.ListAllBySite requested %s, expected publisher_id=5&site_id=9", r.URL.RawQuery)
+		}
+
+		switch r.URL.Query().Get("start_element") {
+		case "", "0":
+			fmt.Fprint(w, `{"response": {"status":"OK", "count": 2, "start_element": 0, "num_elements": 1, "placements": [{"id": 71}]}}`)
+		default:
+			fmt.Fprint(w, `{"response": {"status":"OK", "count": 2, "start_element": 1, "num_elements": 1, "placements": [{"id": 72}]}}`)
+		}
+	})
+
+	actual, err := client.Placements.ListAllBySite(5, 9, &ListOptions{NumElements: 1})
+	if err != nil {
+		t.Errorf("Placements.ListAllBySite returned error: %v", err)
+	}
+
+	if len(actual) != 2 || actual[1].ID != 72 {
+		t.Errorf("Placements.ListAllBySite returned %+v", actual)
+	}
+}
+
+func TestPlacementService_Add(t *testing.T) {
+	setup()
+	defer teardown()
+
+	data := Placement{Name: "Article leaderboard", SiteID: 61, Width: 728, Height: 90, ReservePrice: 0.5}
+
+	mux.HandleFunc("/placement", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Query().Get("publisher_id") != "51" {
+			t.Errorf("Placements.Add sent %s %s", r.Method, r.URL)
+		}
+
+		var body struct {
+			Placement Placement `json:"placement"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Placement.SiteID != 61 || body.Placement.ReservePrice != 0.5 {
+			t.Errorf("Placements.Add sent %+v (%v)", body, err)
+		}
+
+		fmt.Fprint(w, `{"response": {"status":"OK", "id": 73 }}`)
+	})
+
+	actual, err := client.Placements.Add(51, &data)
+	if err != nil {
+		t.Errorf("Placements.Add returned error: %v", err)
+	}
+
+	if actual.Obj.ID != 73 || data.ID != 73 {
+		t.Errorf("Placements.Add returned %+v and set ID %d, expected 73", actual.Obj, data.ID)
+	}
+}
+
+func TestPlacementService_Update(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/placement", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Query().Get("id") != "71" {
+			t.Errorf("Placements.Update sent %s %s", r.Method, r.URL)
+		}
+
+		fmt.Fprint(w, `{"response": {"status":"OK" }}`)
+	})
+
+	if _, err := client.Placements.Update(51, Placement{ID: 71, ReservePrice: 1}); err != nil {
+		t.Errorf("Placements.Update returned error: %v", err)
+	}
+
+	if _, err := client.Placements.Update(51, Placement{}); err == nil {
+		t.Error("Placements.Update accepted a placement without an ID")
+	}
+}
+
+func TestPlacementService_Delete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/placement", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" || r.URL.Query().Get("id") != "71" {
+			t.Errorf("Placements.Delete sent %s %s", r.Method, r.URL)
+		}
+	})
+
+	if err := client.Placements.Delete(51, Placement{ID: 71}); err != nil {
+		t.Errorf("Placements.Delete returned error: %v", err)
+	}
+}
+
+func TestPlacement_Tags(t *testing.T) {
+	p := Placement{ID: 71, Sizes: []PlacementSize{{300, 250}}}
+
+	js, err := p.JSTag(true)
+	if err != nil {
+		t.Fatalf("Placement.JSTag returned error: %v", err)
+	}
+
+	if expected := `<script src="https://secure.adnxs.com/ttj?id=71&amp;size=300x250" type="text/javascript"></script>`; js != expected {
+		t.Errorf("Placement.JSTag returned %s, expected %s", js, expected)
+	}
+
+	iframe, err := p.IframeTag(false)
+	if err != nil {
+		t.Fatalf("Placement.IframeTag returned error: %v", err)
+	}
+
+	expected := `<iframe src="http://ib.adnxs.com/tt?id=71&amp;size=300x250" width="300" height="250" marginwidth="0" marginheight="0" frameborder="0" scrolling="no"></iframe>`
+	if iframe != expected {
+		t.Errorf("Placement.IframeTag returned %s, expected %s", iframe, expected)
+	}
+
+	if _, err := (Placement{ID: 71}).JSTag(true); err == nil {
+		t.Error("Placement.JSTag accepted a placement without a size")
+	}
+
+	if _, err := (Placement{Width: 300, Height: 250}).IframeTag(true); err == nil {
+		t.Error("Placement.IframeTag accepted a placement without an ID")
+	}
+}
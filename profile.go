@@ -0,0 +1,342 @@
+package appnexus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ProfileService handles all requests to the profile service API. Profiles
+// hold the targeting of line items and campaigns.
+type ProfileService struct {
+	*Response
+	client *Client
+}
+
+// Target actions, deciding whether a profile targets or excludes the
+// objects listed
+const (
+	ActionInclude = "include"
+	ActionExclude = "exclude"
+)
+
+// Boolean operators combining segment targets and segment groups
+const (
+	BooleanAnd = "and"
+	BooleanOr  = "or"
+)
+
+// SegmentTarget targets the users of a segment. Recency is constrained by
+// StartMinutes and ExpireMinutes, the time since a user was added to the
+// segment; the Other fields constrain the value stored with the membership.
+type SegmentTarget struct {
+	ID            int    `json:"id"`
+	Code          string `json:"code,omitempty"`
+	Name          string `json:"name,omitempty"`
+	Action        string `json:"action,omitempty"`
+	StartMinutes  *int   `json:"start_minutes,omitempty"`
+	ExpireMinutes *int   `json:"expire_minutes,omitempty"`
+	OtherEquals   *int   `json:"other_equals,omitempty"`
+	OtherLess     *int   `json:"other_less,omitempty"`
+	OtherGreater  *int   `json:"other_greater,omitempty"`
+	OtherInList   []int  `json:"other_in_list,omitempty"`
+}
+
+// Target returns a SegmentTarget including or excluding the users of s
+func (s Segment) Target(action string) SegmentTarget {
+	return SegmentTarget{ID: s.ID, Action: action}
+}
+
+// SegmentGroupTarget combines segment targets with a boolean operator.
+// Groups are in turn combined by the profile's SegmentBooleanOperator.
+type SegmentGroupTarget struct {
+	BooleanOperator string          `json:"boolean_operator"`
+	Segments        []SegmentTarget `json:"segments"`
+}
+
+// CountryTarget targets a country by ISO code
+type CountryTarget struct {
+	ID      int    `json:"id,omitempty"`
+	Country string `json:"country"`
+	Name    string `json:"name,omitempty"`
+}
+
+// RegionTarget targets a region, such as a state, of a country
+type RegionTarget struct {
+	ID      int    `json:"id,omitempty"`
+	Region  string `json:"region,omitempty"`
+	Country string `json:"country,omitempty"`
+	Name    string `json:"name,omitempty"`
+}
+
+// CityTarget targets a city
+type CityTarget struct {
+	ID      int    `json:"id"`
+	City    string `json:"city,omitempty"`
+	Region  string `json:"region,omitempty"`
+	Country string `json:"country,omitempty"`
+}
+
+// DMATarget targets a designated market area
+type DMATarget struct {
+	DMA  int    `json:"dma"`
+	Name string `json:"name,omitempty"`
+}
+
+// DomainTarget targets a single domain
+type DomainTarget struct {
+	Domain string `json:"domain"`
+}
+
+// DaypartTarget targets a day of the week, between StartHour and EndHour
+// inclusive (0-23) in the profile's DaypartTimezone
+type DaypartTarget struct {
+	Day       string `json:"day"`
+	StartHour int    `json:"start_hour"`
+	EndHour   int    `json:"end_hour"`
+}
+
+// InventoryTarget targets or excludes a member, publisher, site or
+// placement by ID
+type InventoryTarget struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name,omitempty"`
+	Action string `json:"action,omitempty"`
+}
+
+// Profile is a set of targeting rules, attached to line items and campaigns
+// by their ProfileID
+type Profile struct {
+	ID           int    `json:"id,omitempty"`
+	Code         string `json:"code,omitempty"`
+	Description  string `json:"description,omitempty"`
+	AdvertiserID int    `json:"advertiser_id,omitempty"`
+	MemberID     int    `json:"member_id,omitempty"`
+	IsTemplate   bool   `json:"is_template,omitempty"`
+
+	// Segment targeting
+	SegmentBooleanOperator string               `json:"segment_boolean_operator,omitempty"`
+	SegmentGroupTargets    []SegmentGroupTarget `json:"segment_group_targets,omitempty"`
+
+	// Geo targeting
+	CountryAction  string          `json:"country_action,omitempty"`
+	CountryTargets []CountryTarget `json:"country_targets,omitempty"`
+	RegionAction   string          `json:"region_action,omitempty"`
+	RegionTargets  []RegionTarget  `json:"region_targets,omitempty"`
+	CityAction     string          `json:"city_action,omitempty"`
+	CityTargets    []CityTarget    `json:"city_targets,omitempty"`
+	DMAAction      string          `json:"dma_action,omitempty"`
+	DMATargets     []DMATarget     `json:"dma_targets,omitempty"`
+
+	// Domain and domain list targeting
+	DomainAction      string         `json:"domain_action,omitempty"`
+	DomainTargets     []DomainTarget `json:"domain_targets,omitempty"`
+	DomainListAction  string         `json:"domain_list_action,omitempty"`
+	DomainListTargets []ObjectRef    `json:"domain_list_targets,omitempty"`
+
+	// Daypart targeting
+	DaypartTimezone string          `json:"daypart_timezone,omitempty"`
+	DaypartTargets  []DaypartTarget `json:"daypart_targets,omitempty"`
+
+	// Frequency caps, in impressions per user
+	MaxLifetimeImps  *int `json:"max_lifetime_imps,omitempty"`
+	MaxSessionImps   *int `json:"max_session_imps,omitempty"`
+	MaxDayImps       *int `json:"max_day_imps,omitempty"`
+	MaxHourImps      *int `json:"max_hour_imps,omitempty"`
+	MaxWeekImps      *int `json:"max_week_imps,omitempty"`
+	MaxMonthImps     *int `json:"max_month_imps,omitempty"`
+	MinMinutesPerImp *int `json:"min_minutes_per_imp,omitempty"`
+
+	// Device targeting
+	DeviceTypeTargets            []string    `json:"device_type_targets,omitempty"`
+	DeviceModelAction            string      `json:"device_model_action,omitempty"`
+	DeviceModelTargets           []ObjectRef `json:"device_model_targets,omitempty"`
+	OperatingSystemFamilyAction  string      `json:"operating_system_family_action,omitempty"`
+	OperatingSystemFamilyTargets []ObjectRef `json:"operating_system_family_targets,omitempty"`
+	BrowserAction                string      `json:"browser_action,omitempty"`
+	BrowserTargets               []ObjectRef `json:"browser_targets,omitempty"`
+	CarrierAction                string      `json:"carrier_action,omitempty"`
+	CarrierTargets               []ObjectRef `json:"carrier_targets,omitempty"`
+
+	// Inventory targeting
+	SupplyTypeAction  string            `json:"supply_type_action,omitempty"`
+	SupplyTypeTargets []string          `json:"supply_type_targets,omitempty"`
+	InventoryAction   string            `json:"inventory_action,omitempty"`
+	MemberTargets     []InventoryTarget `json:"member_targets,omitempty"`
+	PublisherTargets  []InventoryTarget `json:"publisher_targets,omitempty"`
+	SiteTargets       []InventoryTarget `json:"site_targets,omitempty"`
+	PlacementTargets  []InventoryTarget `json:"placement_targets,omitempty"`
+	AllowUnaudited    bool              `json:"allow_unaudited,omitempty"`
+
+	LastModified string `json:"last_modified,omitempty"`
+}
+
+type profileResponse struct {
+	*http.Response
+	Obj struct {
+		Profile  Profile   `json:"profile,omitempty"`
+		Profiles []Profile `json:"profiles,omitempty"`
+		Error    string    `json:"error"`
+		Status   string    `json:"status"`
+		Service  string    `json:"service"`
+		Rate     Rate      `json:"dbg_info"`
+	} `json:"response"`
+}
+
+// Get a profile from the profile service by Advertiser ID and Profile ID
+func (s *ProfileService) Get(advertiserID int, profileID int) (*Profile, error) {
+	return s.GetContext(context.Background(), advertiserID, profileID)
+}
+
+// GetContext is like Get but honours cancellation and deadlines on ctx
+func (s *ProfileService) GetContext(ctx context.Context, advertiserID int, profileID int) (*Profile, error) {
+
+	path := fmt.Sprintf("profile?advertiser_id=%d&id=%d", advertiserID, profileID)
+	req, err := s.client.newRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &profileResponse{}
+	_, err = s.client.do(req, r)
+	if err != nil {
+		return nil, err
+	}
+
+	profile := &r.Obj.Profile
+	return profile, nil
+}
+
+// List the profiles of an advertiser
+func (s *ProfileService) List(advertiserID int, opt *ListOptions) ([]Profile, *Response, error) {
+	return s.ListContext(context.Background(), advertiserID, opt)
+}
+
+// ListContext is like List but honours cancellation and deadlines on ctx
+func (s *ProfileService) ListContext(ctx context.Context, advertiserID int, opt *ListOptions) ([]Profile, *Response, error) {
+	u, err := addOptions(fmt.Sprintf("profile?advertiser_id=%d", advertiserID), opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.newRequest(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	profiles := &profileResponse{}
+	resp, err := s.client.do(req, profiles)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return profiles.Obj.Profiles, resp, err
+}
+
+// Iter returns an Iterator over every profile of the advertiser
+func (s *ProfileService) Iter(advertiserID int, opt *ListOptions) *Iterator[Profile] {
+	return s.IterContext(context.Background(), advertiserID, opt)
+}
+
+// IterContext is like Iter but stops once ctx is done
+func (s *ProfileService) IterContext(ctx context.Context, advertiserID int, opt *ListOptions) *Iterator[Profile] {
+	return newIterator(ctx, opt, func(ctx context.Context, opt *ListOptions) ([]Profile, *Response, error) {
+		return s.ListContext(ctx, advertiserID, opt)
+	})
+}
+
+// ListAll fetches every profile of the advertiser, walking through all pages
+func (s *ProfileService) ListAll(advertiserID int, opt *ListOptions) ([]Profile, error) {
+	return s.ListAllContext(context.Background(), advertiserID, opt)
+}
+
+// ListAllContext is like ListAll but honours cancellation and deadlines on
+// ctx
+func (s *ProfileService) ListAllContext(ctx context.Context, advertiserID int, opt *ListOptions) ([]Profile, error) {
+	return listAll(ctx, opt, func(ctx context.Context, opt *ListOptions) ([]Profile, *Response, error) {
+		return s.ListContext(ctx, advertiserID, opt)
+	})
+}
+
+// Add a new profile to an advertiser. Attach it to a line item or campaign
+// by setting their ProfileID to the new profile's ID.
+func (s *ProfileService) Add(advertiserID int, item *Profile) (*Response, error) {
+	return s.AddContext(context.Background(), advertiserID, item)
+}
+
+// AddContext is like Add but honours cancellation and deadlines on ctx
+func (s *ProfileService) AddContext(ctx context.Context, advertiserID int, item *Profile) (*Response, error) {
+
+	data := struct {
+		Profile `json:"profile"`
+	}{*item}
+
+	req, err := s.client.newRequest(ctx, "POST", fmt.Sprintf("profile?advertiser_id=%d", advertiserID), data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Response{}
+	resp, err := s.client.do(req, result)
+	if err != nil {
+		return resp, err
+	}
+
+	item.ID = result.Obj.ID
+	return result, nil
+}
+
+// Update an existing profile with new data. Target lists that are set
+// replace the profile's current ones.
+func (s *ProfileService) Update(advertiserID int, item Profile) (*Response, error) {
+	return s.UpdateContext(context.Background(), advertiserID, item)
+}
+
+// UpdateContext is like Update but honours cancellation and deadlines on ctx
+func (s *ProfileService) UpdateContext(ctx context.Context, advertiserID int, item Profile) (*Response, error) {
+
+	data := struct {
+		Profile `json:"profile"`
+	}{item}
+
+	if item.ID < 1 {
+		return nil, errors.New("Update Profile requires a profile to have an ID already")
+	}
+
+	path := fmt.Sprintf("profile?advertiser_id=%d&id=%d", advertiserID, item.ID)
+	req, err := s.client.newRequest(ctx, "PUT", path, data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Response{}
+	resp, err := s.client.do(req, result)
+	if err != nil {
+		return resp, err
+	}
+
+	return result, nil
+}
+
+// Delete the specified profile
+func (s *ProfileService) Delete(advertiserID int, item Profile) error {
+	return s.DeleteContext(context.Background(), advertiserID, item)
+}
+
+// DeleteContext is like Delete but honours cancellation and deadlines on ctx
+func (s *ProfileService) DeleteContext(ctx context.Context, advertiserID int, item Profile) error {
+
+	if item.ID < 1 {
+		return errors.New("Delete Profile requires a profile to have an ID already")
+	}
+
+	path := fmt.Sprintf("profile?advertiser_id=%d&id=%d", advertiserID, item.ID)
+	req, err := s.client.newRequest(ctx, "DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.do(req, nil)
+	return err
+}
@@ -0,0 +1,180 @@
+package appnexus
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestProfileService_Get(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/profile", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("advertiser_id") != "2" || r.URL.Query().Get("id") != "44" {
+			t.Errorf("Profiles.Get requested %s, expected advertiser_id=2&id=44", r.URL.RawQuery)
+		}
+
+		fmt.Fprint(w, `{"response":
+            {"status":"OK",
+            "profile": {
+                "id": 44,
+                "segment_boolean_operator": "and",
+                "segment_group_targets": [{
+                    "boolean_operator": "or",
+                    "segments": [
+                        {"id": 1, "action": "include", "start_minutes": 0, "expire_minutes": 1440},
+                        {"id": 2, "action": "exclude", "other_greater": 5}
+                    ]
+                }],
+                "country_action": "include",
+                "country_targets": [{"id": 233, "country": "US", "name": "United States"}],
+                "domain_list_action": "exclude",
+                "domain_list_targets": [{"id": 77}],
+                "daypart_timezone": "America/New_York",
+                "daypart_targets": [{"day": "monday", "start_hour": 9, "end_hour": 17}],
+                "max_day_imps": 3,
+                "device_type_targets": ["phone", "tablet"],
+                "supply_type_targets": ["mobile_app"],
+                "publisher_targets": [{"id": 5, "action": "exclude"}]
+            }}}`)
+	})
+
+	actual, err := client.Profiles.Get(2, 44)
+	if err != nil {
+		t.Fatalf("Profiles.Get returned error: %v", err)
+	}
+
+	if actual.SegmentBooleanOperator != BooleanAnd || len(actual.SegmentGroupTargets) != 1 {
+		t.Fatalf("Profiles.Get returned segment targeting %+v", actual.SegmentGroupTargets)
+	}
+
+	group := actual.SegmentGroupTargets[0]
+	if group.BooleanOperator != BooleanOr || len(group.Segments) != 2 {
+		t.Fatalf("Profiles.Get returned segment group %+v", group)
+	}
+
+	if s := group.Segments[0]; s.Action != ActionInclude || s.ExpireMinutes == nil || *s.ExpireMinutes != 1440 {
+		t.Errorf("Profiles.Get returned segment target %+v", s)
+	}
+
+	if s := group.Segments[1]; s.Action != ActionExclude || s.OtherGreater == nil || *s.OtherGreater != 5 {
+		t.Errorf("Profiles.Get returned segment target %+v", s)
+	}
+
+	if len(actual.CountryTargets) != 1 || actual.CountryTargets[0].Country != "US" || actual.DomainListTargets[0].ID != 77 {
+		t.Errorf("Profiles.Get returned country %+v and domain list %+v targets", actual.CountryTargets, actual.DomainListTargets)
+	}
+
+	if len(actual.DaypartTargets) != 1 || actual.DaypartTargets[0].EndHour != 17 || actual.MaxDayImps == nil || *actual.MaxDayImps != 3 {
+		t.Errorf("Profiles.Get returned dayparts %+v and daily cap %v", actual.DaypartTargets, actual.MaxDayImps)
+	}
+
+	if len(actual.DeviceTypeTargets) != 2 || actual.PublisherTargets[0].Action != ActionExclude {
+		t.Errorf("Profiles.Get returned device %+v and publisher %+v targets", actual.DeviceTypeTargets, actual.PublisherTargets)
+	}
+}
+
+func TestProfileService_Add(t *testing.T) {
+	setup()
+	defer teardown()
+
+	cars := Segment{ID: 1, ShortName: "cars"}
+	bikes := Segment{ID: 2, ShortName: "bikes"}
+	recent := 60
+
+	visitors := cars.Target(ActionInclude)
+	visitors.ExpireMinutes = &recent
+
+	data := Profile{
+		SegmentBooleanOperator: BooleanAnd,
+		SegmentGroupTargets: []SegmentGroupTarget{{
+			BooleanOperator: BooleanAnd,
+			Segments:        []SegmentTarget{visitors, bikes.Target(ActionExclude)},
+		}},
+	}
+
+	mux.HandleFunc("/profile", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Query().Get("advertiser_id") != "2" {
+			t.Errorf("Profiles.Add sent %s %s", r.Method, r.URL)
+		}
+
+		var body struct {
+			Profile Profile `json:"profile"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("Profiles.Add sent an invalid body: %v", err)
+		}
+
+		segments := body.Profile.SegmentGroupTargets[0].Segments
+		if len(segments) != 2 || segments[0].ID != 1 || *segments[0].ExpireMinutes != 60 || segments[1].Action != ActionExclude {
+			t.Errorf("Profiles.Add sent segment targets %+v", segments)
+		}
+
+		fmt.Fprint(w, `{"response": {"status":"OK", "id": 45 }}`)
+	})
+
+	actual, err := client.Profiles.Add(2, &data)
+	if err != nil {
+		t.Errorf("Profiles.Add returned error: %v", err)
+	}
+
+	if actual.Obj.ID != 45 || data.ID != 45 {
+		t.Errorf("Profiles.Add returned %+v and set ID %d, expected 45", actual.Obj, data.ID)
+	}
+}
+
+func TestProfileService_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/profile", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"response": {"status":"OK", "count": 2, "profiles": [{"id": 44}, {"id": 45}]}}`)
+	})
+
+	actual, _, err := client.Profiles.List(2, nil)
+	if err != nil {
+		t.Errorf("Profiles.List returned error: %v", err)
+	}
+
+	if len(actual) != 2 || actual[1].ID != 45 {
+		t.Errorf("Profiles.List returned %+v", actual)
+	}
+}
+
+func TestProfileService_Update(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/profile", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Query().Get("id") != "44" {
+			t.Errorf("Profiles.Update sent %s %s", r.Method, r.URL)
+		}
+
+		fmt.Fprint(w, `{"response": {"status":"OK" }}`)
+	})
+
+	if _, err := client.Profiles.Update(2, Profile{ID: 44, DeviceTypeTargets: []string{"pc"}}); err != nil {
+		t.Errorf("Profiles.Update returned error: %v", err)
+	}
+
+	if _, err := client.Profiles.Update(2, Profile{}); err == nil {
+		t.Error("Profiles.Update accepted a profile without an ID")
+	}
+}
+
+func TestProfileService_Delete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/profile", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" || r.URL.Query().Get("id") != "44" {
+			t.Errorf("Profiles.Delete sent %s %s", r.Method, r.URL)
+		}
+	})
+
+	if err := client.Profiles.Delete(2, Profile{ID: 44}); err != nil {
+		t.Errorf("Profiles.Delete returned error: %v", err)
+	}
+}
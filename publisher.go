@@ -0,0 +1,211 @@
+package appnexus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// PublisherService handles all requests to the publisher service API
+type PublisherService struct {
+	*Response
+	client *Client
+}
+
+// Publisher reselling exposures
+const (
+	ResellingExposurePublic  = "public"
+	ResellingExposurePrivate = "private"
+)
+
+// Publisher owns the sites and placements a sell-side member sells
+type Publisher struct {
+	ID                 int     `json:"id,omitempty"`
+	Code               string  `json:"code,omitempty"`
+	Name               string  `json:"name,omitempty"`
+	State              string  `json:"state,omitempty"`
+	IsOO               bool    `json:"is_oo,omitempty"`
+	ExposeDomains      bool    `json:"expose_domains,omitempty"`
+	ResellingExposure  string  `json:"reselling_exposure,omitempty"`
+	Timezone           string  `json:"timezone,omitempty"`
+	Currency           string  `json:"currency,omitempty"`
+	DefaultSiteID      int     `json:"default_site_id,omitempty"`
+	DefaultPlacementID int     `json:"default_placement_id,omitempty"`
+	BasePaymentRuleID  int     `json:"base_payment_rule_id,omitempty"`
+	YMProfileID        int     `json:"ym_profile_id,omitempty"`
+	Description        string  `json:"description,omitempty"`
+	ContactName        string  `json:"contact_name,omitempty"`
+	ContactEmail       string  `json:"contact_email,omitempty"`
+	Labels             []Label `json:"labels,omitempty"`
+	LastModified       string  `json:"last_modified,omitempty"`
+}
+
+type publisherResponse struct {
+	*http.Response
+	Obj struct {
+		Publisher  Publisher   `json:"publisher,omitempty"`
+		Publishers []Publisher `json:"publishers,omitempty"`
+		Error      string      `json:"error"`
+		Status     string      `json:"status"`
+		Service    string      `json:"service"`
+		Rate       Rate        `json:"dbg_info"`
+	} `json:"response"`
+}
+
+// Get a publisher from the publisher service by Member ID and Publisher ID
+func (s *PublisherService) Get(memberID int, publisherID int) (*Publisher, error) {
+	return s.GetContext(context.Background(), memberID, publisherID)
+}
+
+// GetContext is like Get but honours cancellation and deadlines on ctx
+func (s *PublisherService) GetContext(ctx context.Context, memberID int, publisherID int) (*Publisher, error) {
+
+	path := fmt.Sprintf("publisher?member_id=%d&id=%d", memberID, publisherID)
+	req, err := s.client.newRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &publisherResponse{}
+	_, err = s.client.do(req, r)
+	if err != nil {
+		return nil, err
+	}
+
+	publisher := &r.Obj.Publisher
+	return publisher, nil
+}
+
+// List the publishers of a member
+func (s *PublisherService) List(memberID int, opt *ListOptions) ([]Publisher, *Response, error) {
+	return s.ListContext(context.Background(), memberID, opt)
+}
+
+// ListContext is like List but honours cancellation and deadlines on ctx
+func (s *PublisherService) ListContext(ctx context.Context, memberID int, opt *ListOptions) ([]Publisher, *Response, error) {
+	u, err := addOptions(fmt.Sprintf("publisher?member_id=%d", memberID), opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.newRequest(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	publishers := &publisherResponse{}
+	resp, err := s.client.do(req, publishers)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return publishers.Obj.Publishers, resp, err
+}
+
+// Iter returns an Iterator over every publisher of the member
+func (s *PublisherService) Iter(memberID int, opt *ListOptions) *Iterator[Publisher] {
+	return s.IterContext(context.Background(), memberID, opt)
+}
+
+// IterContext is like Iter but stops once ctx is done
+func (s *PublisherService) IterContext(ctx context.Context, memberID int, opt *ListOptions) *Iterator[Publisher] {
+	return newIterator(ctx, opt, func(ctx context.Context, opt *ListOptions) ([]Publisher, *Response, error) {
+		return s.ListContext(ctx, memberID, opt)
+	})
+}
+
+// ListAll fetches every publisher of the member, walking through all pages
+func (s *PublisherService) ListAll(memberID int, opt *ListOptions) ([]Publisher, error) {
+	return s.ListAllContext(context.Background(), memberID, opt)
+}
+
+// ListAllContext is like ListAll but honours cancellation and deadlines on
+// ctx
+func (s *PublisherService) ListAllContext(ctx context.Context, memberID int, opt *ListOptions) ([]Publisher, error) {
+	return listAll(ctx, opt, func(ctx context.Context, opt *ListOptions) ([]Publisher, *Response, error) {
+		return s.ListContext(ctx, memberID, opt)
+	})
+}
+
+// Add a new publisher to a member. AppNexus creates a default site and
+// placement along with it, given by DefaultSiteID and DefaultPlacementID
+// when the publisher is read back.
+func (s *PublisherService) Add(memberID int, item *Publisher) (*Response, error) {
+	return s.AddContext(context.Background(), memberID, item)
+}
+
+// AddContext is like Add but honours cancellation and deadlines on ctx
+func (s *PublisherService) AddContext(ctx context.Context, memberID int, item *Publisher) (*Response, error) {
+
+	data := struct {
+		Publisher `json:"publisher"`
+	}{*item}
+
+	req, err := s.client.newRequest(ctx, "POST", fmt.Sprintf("publisher?member_id=%d", memberID), data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Response{}
+	resp, err := s.client.do(req, result)
+	if err != nil {
+		return resp, err
+	}
+
+	item.ID = result.Obj.ID
+	return result, nil
+}
+
+// Update an existing publisher with new data
+func (s *PublisherService) Update(memberID int, item Publisher) (*Response, error) {
+	return s.UpdateContext(context.Background(), memberID, item)
+}
+
+// UpdateContext is like Update but honours cancellation and deadlines on ctx
+func (s *PublisherService) UpdateContext(ctx context.Context, memberID int, item Publisher) (*Response, error) {
+
+	data := struct {
+		Publisher `json:"publisher"`
+	}{item}
+
+	if item.ID < 1 {
+		return nil, errors.New("Update Publisher requires a publisher to have an ID already")
+	}
+
+	path := fmt.Sprintf("publisher?member_id=%d&id=%d", memberID, item.ID)
+	req, err := s.client.newRequest(ctx, "PUT", path, data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Response{}
+	resp, err := s.client.do(req, result)
+	if err != nil {
+		return resp, err
+	}
+
+	return result, nil
+}
+
+// Delete the specified publisher, along with its sites and placements
+func (s *PublisherService) Delete(memberID int, item Publisher) error {
+	return s.DeleteContext(context.Background(), memberID, item)
+}
+
+// DeleteContext is like Delete but honours cancellation and deadlines on ctx
+func (s *PublisherService) DeleteContext(ctx context.Context, memberID int, item Publisher) error {
+
+	if item.ID < 1 {
+		return errors.New("Delete Publisher requires a publisher to have an ID already")
+	}
+
+	path := fmt.Sprintf("publisher?member_id=%d&id=%d", memberID, item.ID)
+	req, err := s.client.newRequest(ctx, "DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.do(req, nil)
+	return err
+}
@@ -0,0 +1,129 @@
+package appnexus
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestPublisherService_Get(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/publisher", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("member_id") != "1" || r.URL.Query().Get("id") != "51" {
+			t.Errorf("Publishers.Get requested %s, expected member_id=1&id=51", r.URL.RawQuery)
+		}
+
+		fmt.Fprint(w, `{"response":
+            {"status":"OK",
+            "publisher": {
+                "id": 51,
+                "name": "Daily News",
+                "state": "active",
+                "is_oo": true,
+                "reselling_exposure": "public",
+                "default_site_id": 61,
+                "default_placement_id": 71
+            }}}`)
+	})
+
+	actual, err := client.Publishers.Get(1, 51)
+	if err != nil {
+		t.Fatalf("Publishers.Get returned error: %v", err)
+	}
+
+	if actual.Name != "Daily News" || !actual.IsOO || actual.ResellingExposure != ResellingExposurePublic || actual.DefaultPlacementID != 71 {
+		t.Errorf("Publishers.Get returned %+v", actual)
+	}
+}
+
+func TestPublisherService_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/publisher", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("member_id") != "1" {
+			t.Errorf("Publishers.List requested %s, expected member_id=1", r.URL.RawQuery)
+		}
+
+		fmt.Fprint(w, `{"response": {"status":"OK", "count": 2, "publishers": [{"id": 51}, {"id": 52}]}}`)
+	})
+
+	actual, _, err := client.Publishers.List(1, nil)
+	if err != nil {
+		t.Errorf("Publishers.List returned error: %v", err)
+	}
+
+	if len(actual) != 2 || actual[1].ID != 52 {
+		t.Errorf("Publishers.List returned %+v", actual)
+	}
+}
+
+func TestPublisherService_Add(t *testing.T) {
+	setup()
+	defer teardown()
+
+	data := Publisher{Name: "Evening Post", ResellingExposure: ResellingExposurePrivate}
+
+	mux.HandleFunc("/publisher", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Query().Get("member_id") != "1" {
+			t.Errorf("Publishers.Add sent %s %s", r.Method, r.URL)
+		}
+
+		var body struct {
+			Publisher Publisher `json:"publisher"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Publisher.Name != "Evening Post" {
+			t.Errorf("Publishers.Add sent %+v (%v)", body, err)
+		}
+
+		fmt.Fprint(w, `{"response": {"status":"OK", "id": 53 }}`)
+	})
+
+	actual, err := client.Publishers.Add(1, &data)
+	if err != nil {
+		t.Errorf("Publishers.Add returned error: %v", err)
+	}
+
+	if actual.Obj.ID != 53 || data.ID != 53 {
+		t.Errorf("Publishers.Add returned %+v and set ID %d, expected 53", actual.Obj, data.ID)
+	}
+}
+
+func TestPublisherService_Update(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/publisher", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Query().Get("id") != "51" {
+			t.Errorf("Publishers.Update sent %s %s", r.Method, r.URL)
+		}
+
+		fmt.Fprint(w, `{"response": {"status":"OK" }}`)
+	})
+
+	if _, err := client.Publishers.Update(1, Publisher{ID: 51, State: "inactive"}); err != nil {
+		t.Errorf("Publishers.Update returned error: %v", err)
+	}
+
+	if _, err := client.Publishers.Update(1, Publisher{}); err == nil {
+		t.Error("Publishers.Update accepted a publisher without an ID")
+	}
+}
+
+func TestPublisherService_Delete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/publisher", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" || r.URL.Query().Get("id") != "51" {
+			t.Errorf("Publishers.Delete sent %s %s", r.Method, r.URL)
+		}
+	})
+
+	if err := client.Publishers.Delete(1, Publisher{ID: 51}); err != nil {
+		t.Errorf("Publishers.Delete returned error: %v", err)
+	}
+}
@@ -0,0 +1,161 @@
+package appnexus
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// RateLimitStats reports how often, and for how long, callers of a Client
+// have been held back by the rate limiter
+type RateLimitStats struct {
+	ReadWaits     int64
+	ReadWaitTime  time.Duration
+	WriteWaits    int64
+	WriteWaitTime time.Duration
+}
+
+// ThrottleMode selects how the rate limiter spends the budget of each
+// rate limit window
+type ThrottleMode int
+
+const (
+	// ThrottleBurst lets requests through as fast as they come until the
+	// budget is spent, then holds them back until it refills
+	ThrottleBurst ThrottleMode = iota
+
+	// ThrottleSmooth spreads requests evenly across the window, sending at
+	// most one every read_limit_seconds/read_limit (or write equivalent)
+	ThrottleSmooth
+)
+
+// WithThrottleMode sets how the client spends its rate limit budget. The
+// default is ThrottleBurst.
+func WithThrottleMode(m ThrottleMode) ClientOption {
+	return func(c *Client) error {
+		c.limiter.smooth = m == ThrottleSmooth
+		return nil
+	}
+}
+
+// bucket is a token bucket refilled at limit tokens per period, holding up
+// to burst tokens. Tokens may go negative while callers wait on them.
+type bucket struct {
+	limit  float64
+	burst  float64
+	period time.Duration
+	tokens float64
+	last   time.Time
+}
+
+// rateLimiter enforces the read and write budgets reported by AppNexus in
+// dbg_info across every goroutine sharing a Client
+type rateLimiter struct {
+	mu       sync.Mutex
+	read     bucket
+	write    bucket
+	stats    RateLimitStats
+	disabled bool
+	smooth   bool
+}
+
+// bucket returns the read bucket for GET requests and the write bucket for
+// everything else
+func (l *rateLimiter) bucket(method string) *bucket {
+	if method == "GET" {
+		return &l.read
+	}
+
+	return &l.write
+}
+
+// refill adds the tokens earned since the bucket was last used
+func (b *bucket) refill(now time.Time) {
+	if b.period > 0 && now.After(b.last) {
+		b.tokens += b.limit * float64(now.Sub(b.last)) / float64(b.period)
+		b.tokens = math.Min(b.tokens, b.burst)
+	}
+
+	b.last = now
+}
+
+// set applies a limit reported by AppNexus, having used actions of it in the
+// current window. Tokens already handed out locally are never given back.
+// Smooth buckets hold a single token so requests cannot burst.
+func (b *bucket) set(limit, actions, seconds int, smooth bool, now time.Time) {
+	if limit <= 0 || seconds <= 0 {
+		return
+	}
+
+	known := b.period > 0
+	b.refill(now)
+	b.limit = float64(limit)
+	b.period = time.Duration(seconds) * time.Second
+
+	b.burst = b.limit
+	if smooth {
+		b.burst = 1
+	}
+
+	remaining := math.Min(float64(limit-actions), b.burst)
+	if !known || remaining < b.tokens {
+		b.tokens = remaining
+	}
+}
+
+// update applies the rate limit information returned with a response
+func (l *rateLimiter) update(r Rate, now time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.read.set(r.ReadLimit, r.Reads, r.ReadLimitSeconds, l.smooth, now)
+	l.write.set(r.WriteLimit, r.Writes, r.WriteLimitSeconds, l.smooth, now)
+}
+
+// reserve takes a token for a request with the given method and returns how
+// long the caller must wait before sending it
+func (l *rateLimiter) reserve(method string, now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b := l.bucket(method)
+	if l.disabled || b.period <= 0 {
+		return 0
+	}
+
+	b.refill(now)
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+
+	wait := time.Duration(-b.tokens * float64(b.period) / b.limit)
+	if method == "GET" {
+		l.stats.ReadWaits++
+		l.stats.ReadWaitTime += wait
+	} else {
+		l.stats.WriteWaits++
+		l.stats.WriteWaitTime += wait
+	}
+
+	return wait
+}
+
+// cancel returns a token reserved by a caller that gave up waiting
+func (l *rateLimiter) cancel(method string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if b := l.bucket(method); b.period > 0 {
+		b.tokens = math.Min(b.tokens+1, b.burst)
+	}
+}
+
+// RateLimitStats returns the time callers have spent waiting on the rate
+// limiter since the client was created
+func (c *Client) RateLimitStats() RateLimitStats {
+	c.limiter.mu.Lock()
+	defer c.limiter.mu.Unlock()
+
+	return c.limiter.stats
+}
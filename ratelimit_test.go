@@ -0,0 +1,110 @@
+package appnexus
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_Reserve(t *testing.T) {
+	var l rateLimiter
+	now := time.Now()
+
+	if actual := l.reserve("GET", now); actual != 0 {
+		t.Errorf("reserve waited %v before any limit was known, expected 0", actual)
+	}
+
+	l.update(Rate{ReadLimit: 10, ReadLimitSeconds: 10, Reads: 8}, now)
+
+	// Two reads left in the window, then one more every second:
+	for i, expected := range []time.Duration{0, 0, time.Second, 2 * time.Second, 3 * time.Second} {
+		if actual := l.reserve("GET", now); actual != expected {
+			t.Errorf("reserve %d waited %v, expected %v", i, actual, expected)
+		}
+	}
+
+	// Time passing refills the bucket:
+	if actual, expected := l.reserve("GET", now.Add(3*time.Second)), time.Second; actual != expected {
+		t.Errorf("reserve waited %v after refilling, expected %v", actual, expected)
+	}
+
+	// Writes are budgeted separately:
+	if actual := l.reserve("POST", now); actual != 0 {
+		t.Errorf("reserve waited %v for a write, expected 0", actual)
+	}
+
+	stats := l.stats
+	if stats.ReadWaits != 4 || stats.ReadWaitTime != 7*time.Second || stats.WriteWaits != 0 {
+		t.Errorf("stats are %+v, expected 4 read waits totalling 7s", stats)
+	}
+}
+
+func TestRateLimiter_UpdateKeepsReservations(t *testing.T) {
+	var l rateLimiter
+	now := time.Now()
+
+	l.update(Rate{WriteLimit: 10, WriteLimitSeconds: 10}, now)
+	for i := 0; i < 10; i++ {
+		l.reserve("PUT", now)
+	}
+
+	// A late response reporting unused budget must not hand tokens back out:
+	l.update(Rate{WriteLimit: 10, WriteLimitSeconds: 10, Writes: 2}, now)
+	if actual, expected := l.reserve("PUT", now), time.Second; actual != expected {
+		t.Errorf("reserve waited %v after update, expected %v", actual, expected)
+	}
+
+	l.cancel("PUT")
+	if actual, expected := l.reserve("PUT", now), time.Second; actual != expected {
+		t.Errorf("reserve waited %v after cancel, expected %v", actual, expected)
+	}
+}
+
+func TestClient_Concurrent(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/segment/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"response":{"status":"OK","segment":{"id":1},
+			"dbg_info":{"reads":1,"read_limit":1000,"read_limit_seconds":60,"writes":0,"write_limit":100,"write_limit_seconds":60}}}`)
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.Segments.Get(1, 1); err != nil {
+				t.Errorf("Segments.Get returned error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if actual := client.CurrentRate(); actual.ReadLimit != 1000 {
+		t.Errorf("CurrentRate is %+v, expected a read limit of 1000", actual)
+	}
+}
+
+func TestRateLimiter_Smooth(t *testing.T) {
+	l := rateLimiter{smooth: true}
+	now := time.Now()
+
+	// Plenty of budget left, but requests are still spaced 100ms apart:
+	l.update(Rate{WriteLimit: 10, WriteLimitSeconds: 1, Writes: 0}, now)
+	for i, expected := range []time.Duration{0, 100 * time.Millisecond, 200 * time.Millisecond} {
+		if actual := l.reserve("POST", now); actual != expected {
+			t.Errorf("reserve %d waited %v, expected %v", i, actual, expected)
+		}
+	}
+
+	// An idle bucket never saves up more than a single request:
+	later := now.Add(time.Minute)
+	for i, expected := range []time.Duration{0, 100 * time.Millisecond} {
+		if actual := l.reserve("POST", later); actual != expected {
+			t.Errorf("reserve %d after idling waited %v, expected %v", i, actual, expected)
+		}
+	}
+}
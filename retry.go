@@ -0,0 +1,166 @@
+package appnexus
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how requests that fail for transient reasons (network
+// errors, 5xx and 429 responses) are retried
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts made for a request,
+	// including the first one. A value of 1 or less disables retries.
+	MaxAttempts int
+
+	// MinBackoff is the wait before the first retry. It doubles on every
+	// further attempt up to MaxBackoff.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// Jitter is the fraction (0-1) of the backoff that is randomised so that
+	// concurrent clients do not retry in lockstep
+	Jitter float64
+
+	// RetryNonIdempotent allows POST requests to be replayed after a network
+	// error or 5xx response, where AppNexus may already have acted on them.
+	// Rate limited (429) requests are always safe to replay.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy is the retry policy used by NewClient
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	MinBackoff:  500 * time.Millisecond,
+	MaxBackoff:  30 * time.Second,
+	Jitter:      0.2,
+}
+
+// backoff returns how long to wait before retrying a request that failed
+// with err on the given attempt, and false if it should not be retried
+func (p RetryPolicy) backoff(method string, attempt int, err error) (time.Duration, bool) {
+	if attempt >= p.MaxAttempts || err == nil {
+		return 0, false
+	}
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return 0, false
+	}
+
+	var apiErr *Error
+	isAPIErr := errors.As(err, &apiErr)
+
+	switch {
+	case isAPIErr && errors.Is(err, ErrRateLimited):
+		if apiErr.RetryAfter > 0 {
+			return apiErr.RetryAfter, true
+		}
+	case isAPIErr && apiErr.StatusCode >= 500:
+		if !p.replayable(method) {
+			return 0, false
+		}
+		if apiErr.RetryAfter > 0 {
+			return apiErr.RetryAfter, true
+		}
+	case isAPIErr:
+		return 0, false
+	case isTransportError(err):
+		if !p.replayable(method) {
+			return 0, false
+		}
+	default:
+		// Anything else, such as a response that could not be decoded, would
+		// fail the same way again
+		return 0, false
+	}
+
+	d := float64(p.MinBackoff) * math.Pow(2, float64(attempt-1))
+	if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+
+	if p.Jitter > 0 {
+		d += d * p.Jitter * (2*rand.Float64() - 1)
+	}
+
+	return time.Duration(d), true
+}
+
+// isTransportError reports whether err comes from sending the request or
+// reading the response, rather than from what AppNexus answered
+func isTransportError(err error) bool {
+	var urlErr *url.Error
+	var netErr net.Error
+	return errors.As(err, &urlErr) || errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// replayable reports whether a request with the given method may be sent
+// again after AppNexus possibly processed it
+func (p RetryPolicy) replayable(method string) bool {
+	return p.RetryNonIdempotent || (method != "POST" && method != "PATCH")
+}
+
+// rewindRequest returns a copy of req with a fresh body, ready to be sent
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	r := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		r.Body = body
+	}
+
+	return r, nil
+}
+
+// canReplay reports whether the body of req can be sent again, which is not
+// the case for raw bodies that cannot be read at an offset
+func canReplay(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// parseRetryAfter reads a Retry-After header given either in seconds or as
+// an HTTP date
+func parseRetryAfter(h http.Header) time.Duration {
+	v := h.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(v); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+
+	return 0
+}
+
+// sleepContext waits for d, returning early with an error if ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
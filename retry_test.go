@@ -0,0 +1,167 @@
+package appnexus
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// fastRetryPolicy retries quickly so tests do not spend time backing off
+var fastRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	MinBackoff:  time.Millisecond,
+	MaxBackoff:  5 * time.Millisecond,
+}
+
+func TestRetry_ServerError(t *testing.T) {
+	setup()
+	defer teardown()
+	client.RetryPolicy = fastRetryPolicy
+
+	calls := 0
+	mux.HandleFunc("/segment/1", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		fmt.Fprint(w, `{"response":{"status":"OK","segment":{"id":1}}}`)
+	})
+
+	actual, err := client.Segments.Get(1, 1)
+	if err != nil {
+		t.Fatalf("Segments.Get returned error: %v", err)
+	}
+
+	if actual.ID != 1 || calls != 3 {
+		t.Errorf("Segments.Get returned %+v after %d calls, expected segment 1 after 3", actual, calls)
+	}
+}
+
+func TestRetry_GivesUp(t *testing.T) {
+	setup()
+	defer teardown()
+	client.RetryPolicy = fastRetryPolicy
+
+	calls := 0
+	mux.HandleFunc("/segment/1", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	_, err := client.Segments.Get(1, 1)
+
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Segments.Get returned %v, expected a 503 *Error", err)
+	}
+
+	if calls != fastRetryPolicy.MaxAttempts {
+		t.Errorf("Segments.Get made %d calls, expected %d", calls, fastRetryPolicy.MaxAttempts)
+	}
+}
+
+func TestRetry_PostNotReplayed(t *testing.T) {
+	setup()
+	defer teardown()
+	client.RetryPolicy = fastRetryPolicy
+
+	calls := 0
+	mux.HandleFunc("/segment/1", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	_, err := client.Segments.Add(1, &Segment{ShortName: "Hello Seggy"})
+	if err == nil {
+		t.Errorf("Segments.Add expected an error")
+	}
+
+	if calls != 1 {
+		t.Errorf("Segments.Add made %d calls, expected 1", calls)
+	}
+}
+
+func TestRetry_RateLimitedPostReplaysBody(t *testing.T) {
+	setup()
+	defer teardown()
+	client.RetryPolicy = fastRetryPolicy
+
+	var bodies []string
+	mux.HandleFunc("/segment/1", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if len(bodies) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		fmt.Fprint(w, `{"response":{"status":"OK","id":4}}`)
+	})
+
+	data := Segment{ShortName: "Hello Seggy"}
+	_, err := client.Segments.Add(1, &data)
+	if err != nil {
+		t.Fatalf("Segments.Add returned error: %v", err)
+	}
+
+	if len(bodies) != 2 || bodies[0] == "" || bodies[0] != bodies[1] {
+		t.Errorf("Segments.Add sent bodies %q, expected the same body twice", bodies)
+	}
+
+	if data.ID != 4 {
+		t.Errorf("Segments.Add set ID %d, expected 4", data.ID)
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 5, MinBackoff: time.Second, MaxBackoff: 3 * time.Second}
+	serverErr := &Error{StatusCode: http.StatusInternalServerError}
+
+	for attempt, expected := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 3 * time.Second, 4: 3 * time.Second} {
+		if actual, _ := p.backoff("GET", attempt, serverErr); actual != expected {
+			t.Errorf("backoff for attempt %d is %v, expected %v", attempt, actual, expected)
+		}
+	}
+
+	if _, retry := p.backoff("GET", 5, serverErr); retry {
+		t.Errorf("backoff retried beyond MaxAttempts")
+	}
+
+	if _, retry := p.backoff("GET", 1, &Error{StatusCode: http.StatusBadRequest, ID: "SYNTAX"}); retry {
+		t.Errorf("backoff retried a SYNTAX error")
+	}
+
+	transportErr := fmt.Errorf("client.do.do: %w", &url.Error{Op: "Get", URL: "http://example.com", Err: errors.New("connection reset")})
+	if _, retry := p.backoff("GET", 1, transportErr); !retry {
+		t.Errorf("backoff did not retry a transport error")
+	}
+
+	decodeErr := fmt.Errorf("client.do.checkResponse: %w", json.Unmarshal([]byte("not json"), &struct{}{}))
+	if _, retry := p.backoff("GET", 1, decodeErr); retry {
+		t.Errorf("backoff retried a response that could not be decoded")
+	}
+
+	limited := &Error{StatusCode: http.StatusTooManyRequests, RetryAfter: 7 * time.Second}
+	if actual, retry := p.backoff("POST", 1, limited); !retry || actual != 7*time.Second {
+		t.Errorf("backoff for a rate limited POST is %v (retry %v), expected 7s", actual, retry)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	h := http.Header{}
+	h.Set("Retry-After", "12")
+	if actual, expected := parseRetryAfter(h), 12*time.Second; actual != expected {
+		t.Errorf("parseRetryAfter is %v, expected %v", actual, expected)
+	}
+
+	h.Set("Retry-After", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	if actual := parseRetryAfter(h); actual < 59*time.Minute || actual > time.Hour {
+		t.Errorf("parseRetryAfter is %v, expected about an hour", actual)
+	}
+}
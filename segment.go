@@ -1,6 +1,7 @@
 package appnexus
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -43,9 +44,14 @@ type segmentResponse struct {
 
 // Get a segment from the segment service by Member ID and Segment ID
 func (s *SegmentService) Get(memberID int, segmentID int) (*Segment, error) {
+	return s.GetContext(context.Background(), memberID, segmentID)
+}
+
+// GetContext is like Get but honours cancellation and deadlines on ctx
+func (s *SegmentService) GetContext(ctx context.Context, memberID int, segmentID int) (*Segment, error) {
 
 	path := fmt.Sprintf("segment/%d?id=%d", memberID, segmentID)
-	req, err := s.client.newRequest("GET", path, nil)
+	req, err := s.client.newRequest(ctx, "GET", path, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -62,12 +68,17 @@ func (s *SegmentService) Get(memberID int, segmentID int) (*Segment, error) {
 
 // List available segments from your AppNexus console
 func (s *SegmentService) List(memberID int, opt *ListOptions) ([]Segment, *Response, error) {
+	return s.ListContext(context.Background(), memberID, opt)
+}
+
+// ListContext is like List but honours cancellation and deadlines on ctx
+func (s *SegmentService) ListContext(ctx context.Context, memberID int, opt *ListOptions) ([]Segment, *Response, error) {
 	u, err := addOptions(fmt.Sprintf("segment/%d", memberID), opt)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	req, err := s.client.newRequest("GET", u, opt)
+	req, err := s.client.newRequest(ctx, "GET", u, opt)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -81,14 +92,44 @@ func (s *SegmentService) List(memberID int, opt *ListOptions) ([]Segment, *Respo
 	return segments.Obj.Segments, resp, err
 }
 
+// Iter returns an Iterator over every segment of the member, fetching pages
+// of up to MaxPageSize segments as they are needed
+func (s *SegmentService) Iter(memberID int, opt *ListOptions) *Iterator[Segment] {
+	return s.IterContext(context.Background(), memberID, opt)
+}
+
+// IterContext is like Iter but stops once ctx is done
+func (s *SegmentService) IterContext(ctx context.Context, memberID int, opt *ListOptions) *Iterator[Segment] {
+	return newIterator(ctx, opt, func(ctx context.Context, opt *ListOptions) ([]Segment, *Response, error) {
+		return s.ListContext(ctx, memberID, opt)
+	})
+}
+
+// ListAll fetches every segment of the member, walking through all pages
+func (s *SegmentService) ListAll(memberID int, opt *ListOptions) ([]Segment, error) {
+	return s.ListAllContext(context.Background(), memberID, opt)
+}
+
+// ListAllContext is like ListAll but honours cancellation and deadlines on ctx
+func (s *SegmentService) ListAllContext(ctx context.Context, memberID int, opt *ListOptions) ([]Segment, error) {
+	return listAll(ctx, opt, func(ctx context.Context, opt *ListOptions) ([]Segment, *Response, error) {
+		return s.ListContext(ctx, memberID, opt)
+	})
+}
+
 // Add a new segment
 func (s *SegmentService) Add(memberID int, item *Segment) (*Response, error) {
+	return s.AddContext(context.Background(), memberID, item)
+}
+
+// AddContext is like Add but honours cancellation and deadlines on ctx
+func (s *SegmentService) AddContext(ctx context.Context, memberID int, item *Segment) (*Response, error) {
 
 	data := struct {
 		Segment `json:"segment"`
 	}{*item}
 
-	req, err := s.client.newRequest("POST", fmt.Sprintf("segment/%d", memberID), data)
+	req, err := s.client.newRequest(ctx, "POST", fmt.Sprintf("segment/%d", memberID), data)
 
 	if err != nil {
 		return nil, err
@@ -106,6 +147,11 @@ func (s *SegmentService) Add(memberID int, item *Segment) (*Response, error) {
 
 // Update an existing segment with new data
 func (s *SegmentService) Update(memberID int, item Segment) (*Response, error) {
+	return s.UpdateContext(context.Background(), memberID, item)
+}
+
+// UpdateContext is like Update but honours cancellation and deadlines on ctx
+func (s *SegmentService) UpdateContext(ctx context.Context, memberID int, item Segment) (*Response, error) {
 
 	data := struct {
 		Segment `json:"segment"`
@@ -115,7 +161,7 @@ func (s *SegmentService) Update(memberID int, item Segment) (*Response, error) {
 		return nil, errors.New("Update Segment requires a segment to have an ID already")
 	}
 
-	req, err := s.client.newRequest("PUT", fmt.Sprintf("segment/%d?id=%d", memberID, item.ID), data)
+	req, err := s.client.newRequest(ctx, "PUT", fmt.Sprintf("segment/%d?id=%d", memberID, item.ID), data)
 
 	if err != nil {
 		return nil, err
@@ -132,6 +178,11 @@ func (s *SegmentService) Update(memberID int, item Segment) (*Response, error) {
 
 // Delete the specified segment
 func (s *SegmentService) Delete(memberID int, item Segment) error {
+	return s.DeleteContext(context.Background(), memberID, item)
+}
+
+// DeleteContext is like Delete but honours cancellation and deadlines on ctx
+func (s *SegmentService) DeleteContext(ctx context.Context, memberID int, item Segment) error {
 
 	data := struct {
 		Segment `json:"segment"`
@@ -141,7 +192,7 @@ func (s *SegmentService) Delete(memberID int, item Segment) error {
 		return errors.New("Delete Segment requires a segment to have an ID already")
 	}
 
-	req, err := s.client.newRequest("DELETE", fmt.Sprintf("segment/%d", memberID), data)
+	req, err := s.client.newRequest(ctx, "DELETE", fmt.Sprintf("segment/%d", memberID), data)
 	if err != nil {
 		return err
 	}
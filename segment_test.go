@@ -1,6 +1,8 @@
 package appnexus
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"testing"
@@ -150,3 +152,40 @@ func TestSegmentService_Delete(t *testing.T) {
 		t.Errorf("Segments.Delete returned error: %v", err)
 	}
 }
+
+func TestSegmentService_GetContext_Cancelled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/segment/1", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Segments.GetContext sent a request with a cancelled context")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := client.Segments.GetContext(ctx, 1, 1)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Segments.GetContext returned %v, expected %v", err, context.Canceled)
+	}
+}
+
+func TestSegmentService_Get_NotFound(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/segment/1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"response":{"error_id":"NOTFOUND","error":"segment not found","service":"segment","method":"get"}}`)
+	})
+
+	_, err := client.Segments.Get(1, 99)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Segments.Get returned %v, expected %v", err, ErrNotFound)
+	}
+
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.Service != "segment" || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("Segments.Get returned %#v, expected a segment *Error with status 404", err)
+	}
+}
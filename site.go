@@ -0,0 +1,211 @@
+package appnexus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// SiteService handles all requests to the site service API
+type SiteService struct {
+	*Response
+	client *Client
+}
+
+// Supply types of sites, also used by profile supply type targets
+const (
+	SupplyTypeWeb       = "web"
+	SupplyTypeMobileWeb = "mobile_web"
+	SupplyTypeMobileApp = "mobile_app"
+)
+
+// MobileAppInstance identifies the mobile app a site stands for by its
+// bundle ID and operating system
+type MobileAppInstance struct {
+	ID         int    `json:"id,omitempty"`
+	BundleID   string `json:"bundle_id,omitempty"`
+	OSFamilyID int    `json:"os_family_id,omitempty"`
+}
+
+// Site groups the placements of a publisher on a website or in a mobile app
+type Site struct {
+	ID                int                `json:"id,omitempty"`
+	Code              string             `json:"code,omitempty"`
+	Name              string             `json:"name,omitempty"`
+	State             string             `json:"state,omitempty"`
+	PublisherID       int                `json:"publisher_id,omitempty"`
+	URL               string             `json:"url,omitempty"`
+	SupplyType        string             `json:"supply_type,omitempty"`
+	MobileAppInstance *MobileAppInstance `json:"mobile_app_instance,omitempty"`
+	IntendedAudience  string             `json:"intended_audience,omitempty"`
+	Placements        []ObjectRef        `json:"placements,omitempty"`
+	LastModified      string             `json:"last_modified,omitempty"`
+}
+
+type siteResponse struct {
+	*http.Response
+	Obj struct {
+		Site    Site   `json:"site,omitempty"`
+		Sites   []Site `json:"sites,omitempty"`
+		Error   string `json:"error"`
+		Status  string `json:"status"`
+		Service string `json:"service"`
+		Rate    Rate   `json:"dbg_info"`
+	} `json:"response"`
+}
+
+// Get a site from the site service by Publisher ID and Site ID
+func (s *SiteService) Get(publisherID int, siteID int) (*Site, error) {
+	return s.GetContext(context.Background(), publisherID, siteID)
+}
+
+// GetContext is like Get but honours cancellation and deadlines on ctx
+func (s *SiteService) GetContext(ctx context.Context, publisherID int, siteID int) (*Site, error) {
+
+	path := fmt.Sprintf("site?publisher_id=%d&id=%d", publisherID, siteID)
+	req, err := s.client.newRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &siteResponse{}
+	_, err = s.client.do(req, r)
+	if err != nil {
+		return nil, err
+	}
+
+	site := &r.Obj.Site
+	return site, nil
+}
+
+// List the sites of a publisher
+func (s *SiteService) List(publisherID int, opt *ListOptions) ([]Site, *Response, error) {
+	return s.ListContext(context.Background(), publisherID, opt)
+}
+
+// ListContext is like List but honours cancellation and deadlines on ctx
+func (s *SiteService) ListContext(ctx context.Context, publisherID int, opt *ListOptions) ([]Site, *Response, error) {
+	u, err := addOptions(fmt.Sprintf("site?publisher_id=%d", publisherID), opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.newRequest(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sites := &siteResponse{}
+	resp, err := s.client.do(req, sites)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return sites.Obj.Sites, resp, err
+}
+
+// Iter returns an Iterator over every site of the publisher
+func (s *SiteService) Iter(publisherID int, opt *ListOptions) *Iterator[Site] {
+	return s.IterContext(context.Background(), publisherID, opt)
+}
+
+// IterContext is like Iter but stops once ctx is done
+func (s *SiteService) IterContext(ctx context.Context, publisherID int, opt *ListOptions) *Iterator[Site] {
+	return newIterator(ctx, opt, func(ctx context.Context, opt *ListOptions) ([]Site, *Response, error) {
+		return s.ListContext(ctx, publisherID, opt)
+	})
+}
+
+// ListAll fetches every site of the publisher, walking through all pages
+func (s *SiteService) ListAll(publisherID int, opt *ListOptions) ([]Site, error) {
+	return s.ListAllContext(context.Background(), publisherID, opt)
+}
+
+// ListAllContext is like ListAll but honours cancellation and deadlines on
+// ctx
+func (s *SiteService) ListAllContext(ctx context.Context, publisherID int, opt *ListOptions) ([]Site, error) {
+	return listAll(ctx, opt, func(ctx context.Context, opt *ListOptions) ([]Site, *Response, error) {
+		return s.ListContext(ctx, publisherID, opt)
+	})
+}
+
+// Add a new site to a publisher
+func (s *SiteService) Add(publisherID int, item *Site) (*Response, error) {
+	return s.AddContext(context.Background(), publisherID, item)
+}
+
+// AddContext is like Add but honours cancellation and deadlines on ctx
+func (s *SiteService) AddContext(ctx context.Context, publisherID int, item *Site) (*Response, error) {
+
+	data := struct {
+		Site `json:"site"`
+	}{*item}
+
+	req, err := s.client.newRequest(ctx, "POST", fmt.Sprintf("site?publisher_id=%d", publisherID), data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Response{}
+	resp, err := s.client.do(req, result)
+	if err != nil {
+		return resp, err
+	}
+
+	item.ID = result.Obj.ID
+	return result, nil
+}
+
+// Update an existing site with new data
+func (s *SiteService) Update(publisherID int, item Site) (*Response, error) {
+	return s.UpdateContext(context.Background(), publisherID, item)
+}
+
+// UpdateContext is like Update but honours cancellation and deadlines on ctx
+func (s *SiteService) UpdateContext(ctx context.Context, publisherID int, item Site) (*Response, error) {
+
+	data := struct {
+		Site `json:"site"`
+	}{item}
+
+	if item.ID < 1 {
+		return nil, errors.New("Update Site requires a site to have an ID already")
+	}
+
+	path := fmt.Sprintf("site?publisher_id=%d&id=%d", publisherID, item.ID)
+	req, err := s.client.newRequest(ctx, "PUT", path, data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Response{}
+	resp, err := s.client.do(req, result)
+	if err != nil {
+		return resp, err
+	}
+
+	return result, nil
+}
+
+// Delete the specified site
+func (s *SiteService) Delete(publisherID int, item Site) error {
+	return s.DeleteContext(context.Background(), publisherID, item)
+}
+
+// DeleteContext is like Delete but honours cancellation and deadlines on ctx
+func (s *SiteService) DeleteContext(ctx context.Context, publisherID int, item Site) error {
+
+	if item.ID < 1 {
+		return errors.New("Delete Site requires a site to have an ID already")
+	}
+
+	path := fmt.Sprintf("site?publisher_id=%d&id=%d", publisherID, item.ID)
+	req, err := s.client.newRequest(ctx, "DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.do(req, nil)
+	return err
+}
@@ -0,0 +1,131 @@
+package appnexus
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestSiteService_Get(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/site", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("publisher_id") != "51" || r.URL.Query().Get("id") != "62" {
+			t.Errorf("Sites.Get requested %s, expected publisher_id=51&id=62", r.URL.RawQuery)
+		}
+
+		fmt.Fprint(w, `{"response":
+            {"status":"OK",
+            "site": {
+                "id": 62,
+                "name": "Daily News iOS",
+                "supply_type": "mobile_app",
+                "mobile_app_instance": {"id": 3, "bundle_id": "com.dailynews.app", "os_family_id": 5},
+                "placements": [{"id": 71}]
+            }}}`)
+	})
+
+	actual, err := client.Sites.Get(51, 62)
+	if err != nil {
+		t.Fatalf("Sites.Get returned error: %v", err)
+	}
+
+	if actual.SupplyType != SupplyTypeMobileApp || actual.MobileAppInstance == nil || actual.MobileAppInstance.BundleID != "com.dailynews.app" {
+		t.Errorf("Sites.Get returned %+v", actual)
+	}
+
+	if len(actual.Placements) != 1 || actual.Placements[0].ID != 71 {
+		t.Errorf("Sites.Get returned placements %+v", actual.Placements)
+	}
+}
+
+func TestSiteService_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/site", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("publisher_id") != "51" {
+			t.Errorf("Sites.List requested %s, expected publisher_id=51", r.URL.RawQuery)
+		}
+
+		fmt.Fprint(w, `{"response": {"status":"OK", "count": 1, "sites": [{"id": 61, "url": "https://dailynews.example.com"}]}}`)
+	})
+
+	actual, _, err := client.Sites.List(51, nil)
+	if err != nil {
+		t.Errorf("Sites.List returned error: %v", err)
+	}
+
+	if len(actual) != 1 || actual[0].URL != "https://dailynews.example.com" {
+		t.Errorf("Sites.List returned %+v", actual)
+	}
+}
+
+func TestSiteService_Add(t *testing.T) {
+	setup()
+	defer teardown()
+
+	data := Site{Name: "Daily News web", URL: "https://dailynews.example.com", SupplyType: SupplyTypeWeb}
+
+	mux.HandleFunc("/site", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Query().Get("publisher_id") != "51" {
+			t.Errorf("Sites.Add sent %s %s", r.Method, r.URL)
+		}
+
+		var body struct {
+			Site Site `json:"site"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Site.URL != data.URL {
+			t.Errorf("Sites.Add sent %+v (%v)", body, err)
+		}
+
+		fmt.Fprint(w, `{"response": {"status":"OK", "id": 63 }}`)
+	})
+
+	actual, err := client.Sites.Add(51, &data)
+	if err != nil {
+		t.Errorf("Sites.Add returned error: %v", err)
+	}
+
+	if actual.Obj.ID != 63 || data.ID != 63 {
+		t.Errorf("Sites.Add returned %+v and set ID %d, expected 63", actual.Obj, data.ID)
+	}
+}
+
+func TestSiteService_Update(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/site", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Query().Get("id") != "62" {
+			t.Errorf("Sites.Update sent %s %s", r.Method, r.URL)
+		}
+
+		fmt.Fprint(w, `{"response": {"status":"OK" }}`)
+	})
+
+	if _, err := client.Sites.Update(51, Site{ID: 62, Name: "Daily News iPhone"}); err != nil {
+		t.Errorf("Sites.Update returned error: %v", err)
+	}
+
+	if _, err := client.Sites.Update(51, Site{}); err == nil {
+		t.Error("Sites.Update accepted a site without an ID")
+	}
+}
+
+func TestSiteService_Delete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/site", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" || r.URL.Query().Get("id") != "62" {
+			t.Errorf("Sites.Delete sent %s %s", r.Method, r.URL)
+		}
+	})
+
+	if err := client.Sites.Delete(51, Site{ID: 62}); err != nil {
+		t.Errorf("Sites.Delete returned error: %v", err)
+	}
+}
@@ -0,0 +1,276 @@
+package appnexus
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TokenStore persists the authentication token obtained by Login, so that
+// short-lived processes can reuse it instead of authenticating every time.
+// A stored token is only replaced once AppNexus rejects it with NOAUTH.
+type TokenStore interface {
+	// LoadToken returns the stored token, or an empty string if there is none
+	LoadToken(ctx context.Context) (string, error)
+
+	// SaveToken stores a newly obtained token
+	SaveToken(ctx context.Context, token string) error
+}
+
+// MemoryTokenStore keeps the token in memory. It lets several Clients in the
+// same process share one login.
+type MemoryTokenStore struct {
+	mu    sync.Mutex
+	token string
+}
+
+// LoadToken returns the token held in memory
+func (s *MemoryTokenStore) LoadToken(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.token, nil
+}
+
+// SaveToken holds the token in memory
+func (s *MemoryTokenStore) SaveToken(ctx context.Context, token string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.token = token
+	return nil
+}
+
+// FileTokenStore keeps the token in the file at Path, readable by the
+// current user only
+type FileTokenStore struct {
+	Path string
+}
+
+// LoadToken reads the token from the file, which does not need to exist yet
+func (s FileTokenStore) LoadToken(ctx context.Context) (string, error) {
+	data, err := ioutil.ReadFile(s.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(data)), nil
+}
+
+// SaveToken writes the token to the file, replacing it atomically so that
+// concurrent readers never see a partial token
+func (s FileTokenStore) SaveToken(ctx context.Context, token string) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(s.Path), filepath.Base(s.Path)+".*")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.WriteString(token); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.Path)
+}
+
+// TokenStoreFuncs adapts a pair of functions, such as calls to a shared
+// cache, to the TokenStore interface
+type TokenStoreFuncs struct {
+	Load func(ctx context.Context) (string, error)
+	Save func(ctx context.Context, token string) error
+}
+
+// LoadToken calls f.Load
+func (f TokenStoreFuncs) LoadToken(ctx context.Context) (string, error) {
+	return f.Load(ctx)
+}
+
+// SaveToken calls f.Save
+func (f TokenStoreFuncs) SaveToken(ctx context.Context, token string) error {
+	return f.Save(ctx, token)
+}
+
+// WithTokenStore makes the client load its token from s on first use and
+// save every token obtained by Login to it
+func WithTokenStore(s TokenStore) ClientOption {
+	return func(c *Client) error {
+		if s == nil {
+			return errors.New("WithTokenStore requires a non-nil TokenStore")
+		}
+
+		c.tokens = s
+		return nil
+	}
+}
+
+// WithCredentials sets the username and password used to log in when there
+// is no valid token, without calling Login up front
+func WithCredentials(username, password string) ClientOption {
+	return func(c *Client) error {
+		c.credentials = credentials{
+			Username: username,
+			Password: password,
+		}
+
+		return nil
+	}
+}
+
+// loadToken returns the current token, loading it from the token store the
+// first time it is needed
+func (c *Client) loadToken(ctx context.Context) string {
+	c.mu.Lock()
+	if c.tokens == nil {
+		token := c.token
+		c.mu.Unlock()
+		return token
+	}
+
+	// Only the first caller loads the token; everyone else waits for it
+	loading := c.tokenLoad
+	if loading == nil {
+		c.tokenLoad = make(chan struct{})
+		defer close(c.tokenLoad)
+	}
+	c.mu.Unlock()
+
+	if loading != nil {
+		select {
+		case <-loading:
+		case <-ctx.Done():
+		}
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return c.token
+	}
+
+	stored, err := c.tokens.LoadToken(ctx)
+	if err != nil {
+		c.logger.WarnContext(ctx, "appnexus: could not load token", "error", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.token == "" {
+		c.token = stored
+	}
+
+	return c.token
+}
+
+// saveToken replaces the current token and passes it on to the token store
+func (c *Client) saveToken(ctx context.Context, token string) {
+	c.setToken(token)
+
+	if c.tokens == nil {
+		return
+	}
+
+	if err := c.tokens.SaveToken(ctx, token); err != nil {
+		c.logger.WarnContext(ctx, "appnexus: could not save token", "error", err)
+	}
+}
+
+// WithReauthLimit sets how many times a single request may log in again
+// after being rejected with NOAUTH before failing with ErrReauthFailed. The
+// default is once; zero disables re-authentication.
+func WithReauthLimit(n int) ClientOption {
+	return func(c *Client) error {
+		if n < 0 {
+			return errors.New("WithReauthLimit requires a limit of zero or more")
+		}
+
+		c.reauthLimit = n
+		return nil
+	}
+}
+
+// reauthTimeout bounds a shared login, which runs apart from the deadlines
+// of the callers waiting on it
+const reauthTimeout = time.Minute
+
+// loginCall is a login in progress, shared by every goroutine that needs a
+// new token at the same time
+type loginCall struct {
+	done  chan struct{}
+	token string
+	err   error
+}
+
+// reauthenticate logs in again after AppNexus rejected stale, and returns the
+// new token. Goroutines whose token has already been replaced get the new
+// one straight away, and concurrent callers share a single login.
+func (c *Client) reauthenticate(ctx context.Context, stale string) (string, error) {
+	c.mu.Lock()
+
+	if c.token != "" && c.token != stale {
+		token := c.token
+		c.mu.Unlock()
+		return token, nil
+	}
+
+	call := c.login
+	if call == nil {
+		creds := c.credentials
+		if creds.Username == "" {
+			c.mu.Unlock()
+			return "", errors.New("no credentials to log in with, call Login or use WithCredentials")
+		}
+
+		call = &loginCall{done: make(chan struct{})}
+		c.login = call
+		c.token = ""
+
+		// The login is shared, so it must outlive the caller that started it
+		// being cancelled:
+		go c.sharedLogin(context.WithoutCancel(ctx), call, creds)
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.token, call.err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// sharedLogin runs the login behind call and wakes everyone waiting on it
+func (c *Client) sharedLogin(ctx context.Context, call *loginCall, creds credentials) {
+	ctx, cancel := context.WithTimeout(ctx, reauthTimeout)
+	defer cancel()
+
+	err := c.LoginContext(ctx, creds.Username, creds.Password)
+
+	c.mu.Lock()
+	call.token, call.err = c.token, err
+	c.login = nil
+	c.mu.Unlock()
+
+	close(call.done)
+}
+
+// isAuthRequest reports whether req is a call to the auth service itself,
+// which must never trigger a login
+func isAuthRequest(req *http.Request) bool {
+	return path.Base(req.URL.Path) == "auth"
+}
@@ -0,0 +1,312 @@
+package appnexus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFileTokenStore(t *testing.T) {
+	s := FileTokenStore{Path: filepath.Join(t.TempDir(), "token")}
+	ctx := context.Background()
+
+	token, err := s.LoadToken(ctx)
+	if err != nil || token != "" {
+		t.Errorf("LoadToken returned %q (%v) before saving, expected an empty token", token, err)
+	}
+
+	if err := s.SaveToken(ctx, "abc123"); err != nil {
+		t.Fatalf("SaveToken returned error: %v", err)
+	}
+
+	token, err = s.LoadToken(ctx)
+	if err != nil || token != "abc123" {
+		t.Errorf("LoadToken returned %q (%v), expected %q", token, err, "abc123")
+	}
+}
+
+func TestTokenStore_ReusedWithoutLogin(t *testing.T) {
+	setup()
+	defer teardown()
+
+	store := &MemoryTokenStore{}
+	store.SaveToken(context.Background(), "stored-token")
+	WithTokenStore(store)(client)
+
+	mux.HandleFunc("/auth", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Client logged in despite having a stored token")
+	})
+
+	mux.HandleFunc("/member", func(w http.ResponseWriter, r *http.Request) {
+		if actual, expected := r.Header.Get("Authorization"), "stored-token"; actual != expected {
+			t.Errorf("Authorization header is %q, expected %q", actual, expected)
+		}
+		fmt.Fprint(w, `{"response":{"status":"OK","member":{"id":1}}}`)
+	})
+
+	if _, err := client.Members.Get(0); err != nil {
+		t.Errorf("Members.Get returned error: %v", err)
+	}
+}
+
+func TestTokenStore_RefreshedOnNoAuth(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var saved []string
+	WithCredentials("user", "pass")(client)
+	WithTokenStore(TokenStoreFuncs{
+		Load: func(ctx context.Context) (string, error) { return "expired-token", nil },
+		Save: func(ctx context.Context, token string) error {
+			saved = append(saved, token)
+			return nil
+		},
+	})(client)
+
+	mux.HandleFunc("/auth", func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "authn", Value: "fresh-token"})
+		fmt.Fprint(w, `{"response":{"status":"OK"}}`)
+	})
+
+	mux.HandleFunc("/member", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "fresh-token" {
+			fmt.Fprint(w, `{"response":{"error_id":"NOAUTH","error":"Authentication failed - not logged in"}}`)
+			return
+		}
+		fmt.Fprint(w, `{"response":{"status":"OK","member":{"id":1}}}`)
+	})
+
+	if _, err := client.Members.Get(0); err != nil {
+		t.Errorf("Members.Get returned error: %v", err)
+	}
+
+	if len(saved) != 1 || saved[0] != "fresh-token" {
+		t.Errorf("saved tokens %q, expected only %q", saved, "fresh-token")
+	}
+}
+
+// handleLogin registers an auth handler handing out numbered tokens and
+// returns the number of logins made
+func handleLogin() *int32 {
+	var logins int32
+	mux.HandleFunc("/auth", func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&logins, 1)
+		http.SetCookie(w, &http.Cookie{Name: "authn", Value: fmt.Sprintf("token-%d", n)})
+		fmt.Fprint(w, `{"response":{"status":"OK"}}`)
+	})
+
+	return &logins
+}
+
+func TestReauth_ReplaysBody(t *testing.T) {
+	setup()
+	defer teardown()
+
+	WithCredentials("user", "pass")(client)
+	logins := handleLogin()
+
+	var bodies []string
+	mux.HandleFunc("/segment/1", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if r.Header.Get("Authorization") == "" {
+			fmt.Fprint(w, `{"response":{"error_id":"NOAUTH","error":"Authentication failed - not logged in"}}`)
+			return
+		}
+		fmt.Fprint(w, `{"response":{"status":"OK","id":4}}`)
+	})
+
+	if _, err := client.Segments.Add(1, &Segment{ShortName: "Hello Seggy"}); err != nil {
+		t.Fatalf("Segments.Add returned error: %v", err)
+	}
+
+	if *logins != 1 || len(bodies) != 2 || bodies[0] == "" || bodies[0] != bodies[1] {
+		t.Errorf("Segments.Add logged in %d times and sent %q, expected one login and the same body twice", *logins, bodies)
+	}
+}
+
+func TestReauth_SingleFlight(t *testing.T) {
+	setup()
+	defer teardown()
+
+	WithCredentials("user", "pass")(client)
+	logins := handleLogin()
+
+	mux.HandleFunc("/segment/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "token-1" {
+			fmt.Fprint(w, `{"response":{"error_id":"NOAUTH","error":"Authentication failed - not logged in"}}`)
+			return
+		}
+		fmt.Fprint(w, `{"response":{"status":"OK","segment":{"id":1}}}`)
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.Segments.Get(1, 1); err != nil {
+				t.Errorf("Segments.Get returned error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := atomic.LoadInt32(logins); n != 1 {
+		t.Errorf("concurrent requests logged in %d times, expected once", n)
+	}
+}
+
+func TestReauth_Limit(t *testing.T) {
+	setup()
+	defer teardown()
+
+	WithCredentials("user", "pass")(client)
+	WithReauthLimit(2)(client)
+	logins := handleLogin()
+
+	mux.HandleFunc("/segment/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"response":{"error_id":"NOAUTH","error":"Authentication failed - not logged in"}}`)
+	})
+
+	_, err := client.Segments.Get(1, 1)
+	if !errors.Is(err, ErrReauthFailed) || !errors.Is(err, ErrNoAuth) {
+		t.Errorf("Segments.Get returned %v, expected %v wrapping %v", err, ErrReauthFailed, ErrNoAuth)
+	}
+
+	if *logins != 2 {
+		t.Errorf("Segments.Get logged in %d times, expected 2", *logins)
+	}
+}
+
+func TestReauth_NotForLogin(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/auth", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprint(w, `{"response":{"error_id":"NOAUTH","error":"No match found for user/pass"}}`)
+	})
+
+	if err := client.Login("user", "wrong"); !errors.Is(err, ErrNoAuth) {
+		t.Errorf("Login returned %v, expected %v", err, ErrNoAuth)
+	}
+
+	if calls != 1 {
+		t.Errorf("Login called the auth service %d times, expected once", calls)
+	}
+}
+
+func TestReauth_FirstCallerCancelled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	WithCredentials("user", "pass")(client)
+
+	started, release := make(chan struct{}), make(chan struct{})
+	mux.HandleFunc("/auth", func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		fmt.Fprint(w, `{"response":{"status":"OK","token":"token-1"}}`)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error)
+	go func() {
+		_, err := client.reauthenticate(ctx, "")
+		first <- err
+	}()
+	<-started
+
+	type result struct {
+		token string
+		err   error
+	}
+	second := make(chan result)
+	go func() {
+		token, err := client.reauthenticate(context.Background(), "")
+		second <- result{token, err}
+	}()
+
+	// let the second caller start waiting on the shared login
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	if err := <-first; err != context.Canceled {
+		t.Errorf("cancelled caller got %v, expected %v", err, context.Canceled)
+	}
+
+	close(release)
+	if r := <-second; r.err != nil || r.token != "token-1" {
+		t.Errorf("waiting caller got %q (%v), expected the new token", r.token, r.err)
+	}
+}
+
+func TestTokenStore_ConcurrentFirstLoad(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var loads int32
+	WithTokenStore(TokenStoreFuncs{
+		Load: func(ctx context.Context) (string, error) {
+			atomic.AddInt32(&loads, 1)
+			time.Sleep(20 * time.Millisecond)
+			return "stored-token", nil
+		},
+		Save: func(ctx context.Context, token string) error { return nil },
+	})(client)
+
+	mux.HandleFunc("/auth", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Client logged in despite having a stored token")
+	})
+
+	mux.HandleFunc("/member", func(w http.ResponseWriter, r *http.Request) {
+		if actual, expected := r.Header.Get("Authorization"), "stored-token"; actual != expected {
+			t.Errorf("Authorization header is %q, expected %q", actual, expected)
+		}
+		fmt.Fprint(w, `{"response":{"status":"OK","member":{"id":1}}}`)
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.Members.Get(0); err != nil {
+				t.Errorf("Members.Get returned error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := atomic.LoadInt32(&loads); n != 1 {
+		t.Errorf("concurrent requests loaded the token %d times, expected once", n)
+	}
+}
+
+func TestReauth_NoCredentialsKeepsNoAuth(t *testing.T) {
+	setup()
+	defer teardown()
+
+	store := &MemoryTokenStore{}
+	store.SaveToken(context.Background(), "expired-token")
+	WithTokenStore(store)(client)
+
+	mux.HandleFunc("/segment/1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"response":{"error_id":"NOAUTH","error":"Authentication failed - not logged in"}}`)
+	})
+
+	_, err := client.Segments.Get(1, 1)
+	if !errors.Is(err, ErrNoAuth) || !strings.Contains(err.Error(), "no credentials") {
+		t.Errorf("Segments.Get returned %v, expected the failed login wrapping %v", err, ErrNoAuth)
+	}
+}
@@ -0,0 +1,200 @@
+package appnexus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// YMFloorService handles all requests to the yield management floor service
+// API
+type YMFloorService struct {
+	*Response
+	client *Client
+}
+
+// YMFloor is a price floor of a yield management profile. Bids under the
+// hard floor are rejected; bids under the soft floor only win when no bid
+// clears it.
+type YMFloor struct {
+	ID          int         `json:"id,omitempty"`
+	Code        string      `json:"code,omitempty"`
+	Name        string      `json:"name,omitempty"`
+	Description string      `json:"description,omitempty"`
+	YMProfileID int         `json:"ym_profile_id,omitempty"`
+	HardFloor   *float64    `json:"hard_floor,omitempty"`
+	SoftFloor   *float64    `json:"soft_floor,omitempty"`
+	Priority    int         `json:"priority,omitempty"`
+	Members     []ObjectRef `json:"members,omitempty"`
+	Brands      []ObjectRef `json:"brands,omitempty"`
+	Categories  []ObjectRef `json:"categories,omitempty"`
+}
+
+type ymFloorResponse struct {
+	*http.Response
+	Obj struct {
+		YMFloor  YMFloor   `json:"ym-floor,omitempty"`
+		YMFloors []YMFloor `json:"ym-floors,omitempty"`
+		Error    string    `json:"error"`
+		Status   string    `json:"status"`
+		Service  string    `json:"service"`
+		Rate     Rate      `json:"dbg_info"`
+	} `json:"response"`
+}
+
+// Get a floor by YM Profile ID and YM Floor ID
+func (s *YMFloorService) Get(ymProfileID int, ymFloorID int) (*YMFloor, error) {
+	return s.GetContext(context.Background(), ymProfileID, ymFloorID)
+}
+
+// GetContext is like Get but honours cancellation and deadlines on ctx
+func (s *YMFloorService) GetContext(ctx context.Context, ymProfileID int, ymFloorID int) (*YMFloor, error) {
+
+	path := fmt.Sprintf("ym-floor?ym_profile_id=%d&id=%d", ymProfileID, ymFloorID)
+	req, err := s.client.newRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &ymFloorResponse{}
+	_, err = s.client.do(req, r)
+	if err != nil {
+		return nil, err
+	}
+
+	ymFloor := &r.Obj.YMFloor
+	return ymFloor, nil
+}
+
+// List the floors of a yield management profile
+func (s *YMFloorService) List(ymProfileID int, opt *ListOptions) ([]YMFloor, *Response, error) {
+	return s.ListContext(context.Background(), ymProfileID, opt)
+}
+
+// ListContext is like List but honours cancellation and deadlines on ctx
+func (s *YMFloorService) ListContext(ctx context.Context, ymProfileID int, opt *ListOptions) ([]YMFloor, *Response, error) {
+	u, err := addOptions(fmt.Sprintf("ym-floor?ym_profile_id=%d", ymProfileID), opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.newRequest(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ymFloors := &ymFloorResponse{}
+	resp, err := s.client.do(req, ymFloors)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return ymFloors.Obj.YMFloors, resp, err
+}
+
+// Iter returns an Iterator over every floor of a yield management profile
+func (s *YMFloorService) Iter(ymProfileID int, opt *ListOptions) *Iterator[YMFloor] {
+	return s.IterContext(context.Background(), ymProfileID, opt)
+}
+
+// IterContext is like Iter but stops once ctx is done
+func (s *YMFloorService) IterContext(ctx context.Context, ymProfileID int, opt *ListOptions) *Iterator[YMFloor] {
+	return newIterator(ctx, opt, func(ctx context.Context, opt *ListOptions) ([]YMFloor, *Response, error) {
+		return s.ListContext(ctx, ymProfileID, opt)
+	})
+}
+
+// ListAll fetches every floor of a yield management profile, walking through
+// all pages
+func (s *YMFloorService) ListAll(ymProfileID int, opt *ListOptions) ([]YMFloor, error) {
+	return s.ListAllContext(context.Background(), ymProfileID, opt)
+}
+
+// ListAllContext is like ListAll but honours cancellation and deadlines on
+// ctx
+func (s *YMFloorService) ListAllContext(ctx context.Context, ymProfileID int, opt *ListOptions) ([]YMFloor, error) {
+	return listAll(ctx, opt, func(ctx context.Context, opt *ListOptions) ([]YMFloor, *Response, error) {
+		return s.ListContext(ctx, ymProfileID, opt)
+	})
+}
+
+// Add a new floor to a yield management profile
+func (s *YMFloorService) Add(ymProfileID int, item *YMFloor) (*Response, error) {
+	return s.AddContext(context.Background(), ymProfileID, item)
+}
+
+// AddContext is like Add but honours cancellation and deadlines on ctx
+func (s *YMFloorService) AddContext(ctx context.Context, ymProfileID int, item *YMFloor) (*Response, error) {
+
+	data := struct {
+		YMFloor `json:"ym-floor"`
+	}{*item}
+
+	req, err := s.client.newRequest(ctx, "POST", fmt.Sprintf("ym-floor?ym_profile_id=%d", ymProfileID), data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Response{}
+	resp, err := s.client.do(req, result)
+	if err != nil {
+		return resp, err
+	}
+
+	item.ID = result.Obj.ID
+	return result, nil
+}
+
+// Update an existing floor with new data
+func (s *YMFloorService) Update(ymProfileID int, item YMFloor) (*Response, error) {
+	return s.UpdateContext(context.Background(), ymProfileID, item)
+}
+
+// UpdateContext is like Update but honours cancellation and deadlines on ctx
+func (s *YMFloorService) UpdateContext(ctx context.Context, ymProfileID int, item YMFloor) (*Response, error) {
+
+	data := struct {
+		YMFloor `json:"ym-floor"`
+	}{item}
+
+	if item.ID < 1 {
+		return nil, errors.New("Update YMFloor requires a floor to have an ID already")
+	}
+
+	path := fmt.Sprintf("ym-floor?ym_profile_id=%d&id=%d", ymProfileID, item.ID)
+	req, err := s.client.newRequest(ctx, "PUT", path, data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Response{}
+	resp, err := s.client.do(req, result)
+	if err != nil {
+		return resp, err
+	}
+
+	return result, nil
+}
+
+// Delete the specified floor
+func (s *YMFloorService) Delete(ymProfileID int, item YMFloor) error {
+	return s.DeleteContext(context.Background(), ymProfileID, item)
+}
+
+// DeleteContext is like Delete but honours cancellation and deadlines on ctx
+func (s *YMFloorService) DeleteContext(ctx context.Context, ymProfileID int, item YMFloor) error {
+
+	if item.ID < 1 {
+		return errors.New("Delete YMFloor requires a floor to have an ID already")
+	}
+
+	path := fmt.Sprintf("ym-floor?ym_profile_id=%d&id=%d", ymProfileID, item.ID)
+	req, err := s.client.newRequest(ctx, "DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.do(req, nil)
+	return err
+}
@@ -0,0 +1,144 @@
+package appnexus
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestYMFloorService_Get(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/ym-floor", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("ym_profile_id") != "101" || r.URL.Query().Get("id") != "111" {
+			t.Errorf("YMFloors.Get requested %s, expected ym_profile_id=101&id=111", r.URL.RawQuery)
+		}
+
+		fmt.Fprint(w, `{"response": {"status":"OK",
+            "ym-floor": {"id": 111, "hard_floor": 0.5, "soft_floor": null, "brands": [{"id": 3}]}}}`)
+	})
+
+	actual, err := client.YMFloors.Get(101, 111)
+	if err != nil {
+		t.Fatalf("YMFloors.Get returned error: %v", err)
+	}
+
+	if actual.HardFloor == nil || *actual.HardFloor != 0.5 || actual.SoftFloor != nil || len(actual.Brands) != 1 {
+		t.Errorf("YMFloors.Get returned %+v", actual)
+	}
+}
+
+func TestYMFloorService_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/ym-floor", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"response": {"status":"OK", "count": 2, "ym-floors": [{"id": 111}, {"id": 112}]}}`)
+	})
+
+	actual, _, err := client.YMFloors.List(101, nil)
+	if err != nil {
+		t.Errorf("YMFloors.List returned error: %v", err)
+	}
+
+	if len(actual) != 2 || actual[1].ID != 112 {
+		t.Errorf("YMFloors.List returned %+v", actual)
+	}
+}
+
+func TestYMFloorService_ListAll(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/ym-floor", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("ym_profile_id") != "101" {
+			t.Errorf("YMFloors.ListAll requested %s, expected ym_profile_id=101", r.URL.RawQuery)
+		}
+
+		switch r.URL.Query().Get("start_element") {
+		case "", "0":
+			fmt.Fprint(w, `{"response": {"status":"OK", "count": 2, "start_element": 0, "num_elements": 1, "ym-floors": [{"id": 111}]}}`)
+		default:
+			fmt.Fprint(w, `{"response": {"status":"OK", "count": 2, "start_element": 1, "num_elements": 1, "ym-floors": [{"id": 112}]}}`)
+		}
+	})
+
+	actual, err := client.YMFloors.ListAll(101, &ListOptions{NumElements: 1})
+	if err != nil {
+		t.Errorf("YMFloors.ListAll returned error: %v", err)
+	}
+
+	if len(actual) != 2 || actual[1].ID != 112 {
+		t.Errorf("YMFloors.ListAll returned %+v", actual)
+	}
+}
+
+func TestYMFloorService_Add(t *testing.T) {
+	setup()
+	defer teardown()
+
+	floor := 2.0
+	data := YMFloor{Name: "Auto brands", HardFloor: &floor, Categories: []ObjectRef{{ID: 9}}}
+
+	mux.HandleFunc("/ym-floor", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Query().Get("ym_profile_id") != "101" {
+			t.Errorf("YMFloors.Add sent %s %s", r.Method, r.URL)
+		}
+
+		var body struct {
+			YMFloor YMFloor `json:"ym-floor"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.YMFloor.HardFloor == nil || *body.YMFloor.HardFloor != 2 {
+			t.Errorf("YMFloors.Add sent %+v (%v)", body, err)
+		}
+
+		fmt.Fprint(w, `{"response": {"status":"OK", "id": 113 }}`)
+	})
+
+	actual, err := client.YMFloors.Add(101, &data)
+	if err != nil {
+		t.Errorf("YMFloors.Add returned error: %v", err)
+	}
+
+	if actual.Obj.ID != 113 || data.ID != 113 {
+		t.Errorf("YMFloors.Add returned %+v and set ID %d, expected 113", actual.Obj, data.ID)
+	}
+}
+
+func TestYMFloorService_Update(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/ym-floor", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Query().Get("id") != "111" {
+			t.Errorf("YMFloors.Update sent %s %s", r.Method, r.URL)
+		}
+
+		fmt.Fprint(w, `{"response": {"status":"OK" }}`)
+	})
+
+	if _, err := client.YMFloors.Update(101, YMFloor{ID: 111, Priority: 2}); err != nil {
+		t.Errorf("YMFloors.Update returned error: %v", err)
+	}
+
+	if _, err := client.YMFloors.Update(101, YMFloor{}); err == nil {
+		t.Error("YMFloors.Update accepted a floor without an ID")
+	}
+}
+
+func TestYMFloorService_Delete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/ym-floor", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" || r.URL.Query().Get("id") != "111" {
+			t.Errorf("YMFloors.Delete sent %s %s", r.Method, r.URL)
+		}
+	})
+
+	if err := client.YMFloors.Delete(101, YMFloor{ID: 111}); err != nil {
+		t.Errorf("YMFloors.Delete returned error: %v", err)
+	}
+}
@@ -0,0 +1,221 @@
+package appnexus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// YMProfileService handles all requests to the yield management profile
+// service API
+type YMProfileService struct {
+	*Response
+	client *Client
+}
+
+// YMBias raises or lowers the bids of the given buyers by BiasPct percent
+// in the auction
+type YMBias struct {
+	ID         int         `json:"id,omitempty"`
+	Code       string      `json:"code,omitempty"`
+	Name       string      `json:"name,omitempty"`
+	BiasPct    float64     `json:"bias_pct"`
+	Priority   int         `json:"priority,omitempty"`
+	Members    []ObjectRef `json:"members,omitempty"`
+	Brands     []ObjectRef `json:"brands,omitempty"`
+	Categories []ObjectRef `json:"categories,omitempty"`
+}
+
+// YMProfile is a set of floors and biasing rules applied to the inventory
+// of the publishers, sites and placements given its ID
+type YMProfile struct {
+	ID           int       `json:"id,omitempty"`
+	Code         string    `json:"code,omitempty"`
+	Name         string    `json:"name,omitempty"`
+	Description  string    `json:"description,omitempty"`
+	Floors       []YMFloor `json:"floors,omitempty"`
+	Biases       []YMBias  `json:"biases,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+}
+
+type ymProfileResponse struct {
+	*http.Response
+	Obj struct {
+		YMProfile  YMProfile   `json:"ym-profile,omitempty"`
+		YMProfiles []YMProfile `json:"ym-profiles,omitempty"`
+		Error      string      `json:"error"`
+		Status     string      `json:"status"`
+		Service    string      `json:"service"`
+		Rate       Rate        `json:"dbg_info"`
+	} `json:"response"`
+}
+
+// Get a yield management profile by Member ID and YM Profile ID
+func (s *YMProfileService) Get(memberID int, ymProfileID int) (*YMProfile, error) {
+	return s.GetContext(context.Background(), memberID, ymProfileID)
+}
+
+// GetContext is like Get but honours cancellation and deadlines on ctx
+func (s *YMProfileService) GetContext(ctx context.Context, memberID int, ymProfileID int) (*YMProfile, error) {
+
+	path := fmt.Sprintf("ym-profile?member_id=%d&id=%d", memberID, ymProfileID)
+	req, err := s.client.newRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &ymProfileResponse{}
+	_, err = s.client.do(req, r)
+	if err != nil {
+		return nil, err
+	}
+
+	ymProfile := &r.Obj.YMProfile
+	return ymProfile, nil
+}
+
+// List the yield management profiles of a member
+func (s *YMProfileService) List(memberID int, opt *ListOptions) ([]YMProfile, *Response, error) {
+	return s.ListContext(context.Background(), memberID, opt)
+}
+
+// ListContext is like List but honours cancellation and deadlines on ctx
+func (s *YMProfileService) ListContext(ctx context.Context, memberID int, opt *ListOptions) ([]YMProfile, *Response, error) {
+	u, err := addOptions(fmt.Sprintf("ym-profile?member_id=%d", memberID), opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.newRequest(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ymProfiles := &ymProfileResponse{}
+	resp, err := s.client.do(req, ymProfiles)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return ymProfiles.Obj.YMProfiles, resp, err
+}
+
+// Iter returns an Iterator over every yield management profile of the
+// member
+func (s *YMProfileService) Iter(memberID int, opt *ListOptions) *Iterator[YMProfile] {
+	return s.IterContext(context.Background(), memberID, opt)
+}
+
+// IterContext is like Iter but stops once ctx is done
+func (s *YMProfileService) IterContext(ctx context.Context, memberID int, opt *ListOptions) *Iterator[YMProfile] {
+	return newIterator(ctx, opt, func(ctx context.Context, opt *ListOptions) ([]YMProfile, *Response, error) {
+		return s.ListContext(ctx, memberID, opt)
+	})
+}
+
+// ListAll fetches every yield management profile of the member, walking
+// through all pages
+func (s *YMProfileService) ListAll(memberID int, opt *ListOptions) ([]YMProfile, error) {
+	return s.ListAllContext(context.Background(), memberID, opt)
+}
+
+// ListAllContext is like ListAll but honours cancellation and deadlines on
+// ctx
+func (s *YMProfileService) ListAllContext(ctx context.Context, memberID int, opt *ListOptions) ([]YMProfile, error) {
+	return listAll(ctx, opt, func(ctx context.Context, opt *ListOptions) ([]YMProfile, *Response, error) {
+		return s.ListContext(ctx, memberID, opt)
+	})
+}
+
+// Add a new yield management profile to a member
+func (s *YMProfileService) Add(memberID int, item *YMProfile) (*Response, error) {
+	return s.AddContext(context.Background(), memberID, item)
+}
+
+// AddContext is like Add but honours cancellation and deadlines on ctx
+func (s *YMProfileService) AddContext(ctx context.Context, memberID int, item *YMProfile) (*Response, error) {
+
+	data := struct {
+		YMProfile `json:"ym-profile"`
+	}{*item}
+
+	req, err := s.client.newRequest(ctx, "POST", fmt.Sprintf("ym-profile?member_id=%d", memberID), data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Response{}
+	resp, err := s.client.do(req, result)
+	if err != nil {
+		return resp, err
+	}
+
+	item.ID = result.Obj.ID
+	return result, nil
+}
+
+// Update an existing yield management profile with new data
+func (s *YMProfileService) Update(memberID int, item YMProfile) (*Response, error) {
+	return s.UpdateContext(context.Background(), memberID, item)
+}
+
+// UpdateContext is like Update but honours cancellation and deadlines on ctx
+func (s *YMProfileService) UpdateContext(ctx context.Context, memberID int, item YMProfile) (*Response, error) {
+
+	data := struct {
+		YMProfile `json:"ym-profile"`
+	}{item}
+
+	if item.ID < 1 {
+		return nil, errors.New("Update YMProfile requires a yield management profile to have an ID already")
+	}
+
+	path := fmt.Sprintf("ym-profile?member_id=%d&id=%d", memberID, item.ID)
+	req, err := s.client.newRequest(ctx, "PUT", path, data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Response{}
+	resp, err := s.client.do(req, result)
+	if err != nil {
+		return resp, err
+	}
+
+	return result, nil
+}
+
+// Delete the specified yield management profile
+func (s *YMProfileService) Delete(memberID int, item YMProfile) error {
+	return s.DeleteContext(context.Background(), memberID, item)
+}
+
+// DeleteContext is like Delete but honours cancellation and deadlines on ctx
+func (s *YMProfileService) DeleteContext(ctx context.Context, memberID int, item YMProfile) error {
+
+	if item.ID < 1 {
+		return errors.New("Delete YMProfile requires a yield management profile to have an ID already")
+	}
+
+	path := fmt.Sprintf("ym-profile?member_id=%d&id=%d", memberID, item.ID)
+	req, err := s.client.newRequest(ctx, "DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.do(req, nil)
+	return err
+}
+
+// AssignToPublisher sets the yield management profile of a publisher,
+// applying it to all of the publisher's inventory
+func (s *YMProfileService) AssignToPublisher(memberID int, ymProfileID int, publisherID int) (*Response, error) {
+	return s.AssignToPublisherContext(context.Background(), memberID, ymProfileID, publisherID)
+}
+
+// AssignToPublisherContext is like AssignToPublisher but honours
+// cancellation and deadlines on ctx
+func (s *YMProfileService) AssignToPublisherContext(ctx context.Context, memberID int, ymProfileID int, publisherID int) (*Response, error) {
+	return s.client.Publishers.UpdateContext(ctx, memberID, Publisher{ID: publisherID, YMProfileID: ymProfileID})
+}
@@ -0,0 +1,150 @@
+package appnexus
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestYMProfileService_Get(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/ym-profile", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("member_id") != "1" || r.URL.Query().Get("id") != "101" {
+			t.Errorf("YMProfiles.Get requested %s, expected member_id=1&id=101", r.URL.RawQuery)
+		}
+
+		fmt.Fprint(w, `{"response":
+            {"status":"OK",
+            "ym-profile": {
+                "id": 101,
+                "name": "Premium inventory",
+                "floors": [{"id": 111, "name": "Default", "hard_floor": 0.5, "soft_floor": 1.25}],
+                "biases": [{"id": 121, "bias_pct": -10, "members": [{"id": 7}]}]
+            }}}`)
+	})
+
+	actual, err := client.YMProfiles.Get(1, 101)
+	if err != nil {
+		t.Fatalf("YMProfiles.Get returned error: %v", err)
+	}
+
+	if len(actual.Floors) != 1 || actual.Floors[0].SoftFloor == nil || *actual.Floors[0].SoftFloor != 1.25 {
+		t.Errorf("YMProfiles.Get returned floors %+v", actual.Floors)
+	}
+
+	if len(actual.Biases) != 1 || actual.Biases[0].BiasPct != -10 || actual.Biases[0].Members[0].ID != 7 {
+		t.Errorf("YMProfiles.Get returned biases %+v", actual.Biases)
+	}
+}
+
+func TestYMProfileService_List(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/ym-profile", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"response": {"status":"OK", "count": 1, "ym-profiles": [{"id": 101}]}}`)
+	})
+
+	actual, _, err := client.YMProfiles.List(1, nil)
+	if err != nil {
+		t.Errorf("YMProfiles.List returned error: %v", err)
+	}
+
+	if len(actual) != 1 || actual[0].ID != 101 {
+		t.Errorf("YMProfiles.List returned %+v", actual)
+	}
+}
+
+func TestYMProfileService_Add(t *testing.T) {
+	setup()
+	defer teardown()
+
+	data := YMProfile{Name: "Remnant", Biases: []YMBias{{Name: "Favour member 7", BiasPct: 5, Members: []ObjectRef{{ID: 7}}}}}
+
+	mux.HandleFunc("/ym-profile", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Query().Get("member_id") != "1" {
+			t.Errorf("YMProfiles.Add sent %s %s", r.Method, r.URL)
+		}
+
+		var body struct {
+			YMProfile YMProfile `json:"ym-profile"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || len(body.YMProfile.Biases) != 1 {
+			t.Errorf("YMProfiles.Add sent %+v (%v)", body, err)
+		}
+
+		fmt.Fprint(w, `{"response": {"status":"OK", "id": 102 }}`)
+	})
+
+	actual, err := client.YMProfiles.Add(1, &data)
+	if err != nil {
+		t.Errorf("YMProfiles.Add returned error: %v", err)
+	}
+
+	if actual.Obj.ID != 102 || data.ID != 102 {
+		t.Errorf("YMProfiles.Add returned %+v and set ID %d, expected 102", actual.Obj, data.ID)
+	}
+}
+
+func TestYMProfileService_AssignToPublisher(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/publisher", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Query().Get("id") != "51" {
+			t.Errorf("YMProfiles.AssignToPublisher sent %s %s", r.Method, r.URL)
+		}
+
+		var body struct {
+			Publisher Publisher `json:"publisher"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Publisher.YMProfileID != 101 {
+			t.Errorf("YMProfiles.AssignToPublisher sent %+v (%v)", body, err)
+		}
+
+		fmt.Fprint(w, `{"response": {"status":"OK" }}`)
+	})
+
+	if _, err := client.YMProfiles.AssignToPublisher(1, 101, 51); err != nil {
+		t.Errorf("YMProfiles.AssignToPublisher returned error: %v", err)
+	}
+}
+
+func TestYMProfileService_Update(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/ym-profile", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Query().Get("id") != "101" {
+			t.Errorf("YMProfiles.Update sent %s %s", r.Method, r.URL)
+		}
+
+		fmt.Fprint(w, `{"response": {"status":"OK" }}`)
+	})
+
+	if _, err := client.YMProfiles.Update(1, YMProfile{ID: 101, Name: "Premium"}); err != nil {
+		t.Errorf("YMProfiles.Update returned error: %v", err)
+	}
+
+	if _, err := client.YMProfiles.Update(1, YMProfile{}); err == nil {
+		t.Error("YMProfiles.Update accepted a profile without an ID")
+	}
+}
+
+func TestYMProfileService_Delete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/ym-profile", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "DELETE" || r.URL.Query().Get("id") != "101" {
+			t.Errorf("YMProfiles.Delete sent %s %s", r.Method, r.URL)
+		}
+	})
+
+	if err := client.YMProfiles.Delete(1, YMProfile{ID: 101}); err != nil {
+		t.Errorf("YMProfiles.Delete returned error: %v", err)
+	}
+}